@@ -0,0 +1,383 @@
+package gokong
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type AdminsClient interface {
+	Invite(adminRequest *AdminRequest) (*Admin, error)
+	GetByUsername(username string) (*Admin, error)
+	GetById(id string) (*Admin, error)
+	List(query *AdminQueryString) ([]*Admin, error)
+	UpdateByUsername(username string, adminRequest *AdminRequest) (*Admin, error)
+	UpdateById(id string, adminRequest *AdminRequest) (*Admin, error)
+	DeleteByUsername(username string) error
+	DeleteById(id string) error
+	GenerateRegisterUrl(usernameOrId string) (*AdminRegistration, error)
+	Register(registerRequest *AdminRegisterRequest) error
+	GetRoles(usernameOrId string) ([]*RBACRole, error)
+	AddRoles(usernameOrId string, roles []string) ([]*RBACRole, error)
+	DeleteRoles(usernameOrId string, roles []string) error
+	GetWorkspaces(usernameOrId string) ([]*Workspace, error)
+}
+
+type adminsClient struct {
+	config *Config
+}
+
+type AdminRequest struct {
+	Username         string `json:"username,omitempty" yaml:"username,omitempty"`
+	CustomId         string `json:"custom_id,omitempty" yaml:"custom_id,omitempty"`
+	Email            string `json:"email,omitempty" yaml:"email,omitempty"`
+	RbacTokenEnabled *bool  `json:"rbac_token_enabled,omitempty" yaml:"rbac_token_enabled,omitempty"`
+}
+
+// Admin is a kong manager administrator. Status is 0 once the admin has registered, 4 while the invitation is pending
+// and 1 when the admin is disabled.
+type Admin struct {
+	Id               string `json:"id,omitempty" yaml:"id,omitempty"`
+	Username         string `json:"username,omitempty" yaml:"username,omitempty"`
+	CustomId         string `json:"custom_id,omitempty" yaml:"custom_id,omitempty"`
+	Email            string `json:"email,omitempty" yaml:"email,omitempty"`
+	Status           int    `json:"status" yaml:"status"`
+	RbacTokenEnabled bool   `json:"rbac_token_enabled" yaml:"rbac_token_enabled"`
+	CreatedAt        int    `json:"created_at,omitempty" yaml:"created_at,omitempty"`
+	UpdatedAt        int    `json:"updated_at,omitempty" yaml:"updated_at,omitempty"`
+}
+
+type Admins struct {
+	Data   []*Admin `json:"data,omitempty" yaml:"data,omitempty"`
+	Next   string   `json:"next,omitempty" yaml:"next,omitempty"`
+	Offset string   `json:"offset,omitempty" yaml:"offset,omitempty"`
+}
+
+type AdminQueryString struct {
+	Offset string `json:"offset,omitempty"`
+	Size   int    `json:"size"`
+}
+
+// AdminRegistration holds the url and token an invited admin registers with.
+type AdminRegistration struct {
+	Admin
+	RegisterUrl string `json:"register_url,omitempty" yaml:"register_url,omitempty"`
+	Token       string `json:"token,omitempty" yaml:"token,omitempty"`
+}
+
+type AdminRegisterRequest struct {
+	Username string `json:"username" yaml:"username"`
+	Email    string `json:"email" yaml:"email"`
+	Token    string `json:"token" yaml:"token"`
+	Password string `json:"password" yaml:"password"`
+}
+
+type AdminRolesRequest struct {
+	Roles string `json:"roles" yaml:"roles"`
+}
+
+type AdminRoles struct {
+	Roles []*RBACRole `json:"roles,omitempty" yaml:"roles,omitempty"`
+}
+
+const AdminsPath = "/admins/"
+
+func (adminsClient *adminsClient) Invite(adminRequest *AdminRequest) (*Admin, error) {
+	r, body, errs := newPost(adminsClient.config, AdminsPath).Send(adminRequest).End()
+	if errs != nil {
+		return nil, fmt.Errorf("could not invite admin, error: %v", errs)
+	}
+
+	if r.StatusCode == 400 {
+		return nil, fmt.Errorf("bad request, message from kong: %s", body)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	// kong wraps the invited admin in an admin field
+	invitation := &struct {
+		Admin *Admin `json:"admin"`
+	}{}
+	err := json.Unmarshal([]byte(body), invitation)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse admin invitation response, error: %v", err)
+	}
+
+	if invitation.Admin == nil || invitation.Admin.Id == "" {
+		return nil, fmt.Errorf("could not invite admin, error: %v", body)
+	}
+
+	return invitation.Admin, nil
+}
+
+func (adminsClient *adminsClient) GetByUsername(username string) (*Admin, error) {
+	return adminsClient.GetById(username)
+}
+
+func (adminsClient *adminsClient) GetById(id string) (*Admin, error) {
+	r, body, errs := newGet(adminsClient.config, AdminsPath+id).End()
+	if errs != nil {
+		return nil, fmt.Errorf("could not get admin, error: %v", errs)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	admin := &Admin{}
+	err := json.Unmarshal([]byte(body), admin)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse admin get response, error: %v", err)
+	}
+
+	if admin.Id == "" {
+		return nil, nil
+	}
+
+	return admin, nil
+}
+
+func (adminsClient *adminsClient) List(query *AdminQueryString) ([]*Admin, error) {
+	admins := make([]*Admin, 0)
+
+	pageQuery := AdminQueryString{}
+	if query != nil {
+		pageQuery = *query
+	}
+
+	if pageQuery.Size < 100 {
+		pageQuery.Size = 100
+	}
+
+	if pageQuery.Size > 1000 {
+		pageQuery.Size = 1000
+	}
+
+	for {
+		data := &Admins{}
+
+		r, body, errs := newGet(adminsClient.config, AdminsPath).Query(pageQuery).End()
+		if errs != nil {
+			return nil, fmt.Errorf("could not get admins, error: %v", errs)
+		}
+
+		if r.StatusCode == 401 || r.StatusCode == 403 {
+			return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+		}
+
+		err := json.Unmarshal([]byte(body), data)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse admins list response, error: %v", err)
+		}
+
+		admins = append(admins, data.Data...)
+		if data.Next == "" || data.Offset == "" {
+			break
+		}
+
+		pageQuery.Offset = data.Offset
+	}
+
+	return admins, nil
+}
+
+func (adminsClient *adminsClient) UpdateByUsername(username string, adminRequest *AdminRequest) (*Admin, error) {
+	return adminsClient.UpdateById(username, adminRequest)
+}
+
+func (adminsClient *adminsClient) UpdateById(id string, adminRequest *AdminRequest) (*Admin, error) {
+	r, body, errs := newPatch(adminsClient.config, AdminsPath+id).Send(adminRequest).End()
+	if errs != nil {
+		return nil, fmt.Errorf("could not update admin, error: %v", errs)
+	}
+
+	if r.StatusCode == 400 {
+		return nil, fmt.Errorf("bad request, message from kong: %s", body)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	updatedAdmin := &Admin{}
+	err := json.Unmarshal([]byte(body), updatedAdmin)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse admin update response, error: %v", err)
+	}
+
+	if updatedAdmin.Id == "" {
+		return nil, fmt.Errorf("could not update admin, error: %v", body)
+	}
+
+	return updatedAdmin, nil
+}
+
+func (adminsClient *adminsClient) DeleteByUsername(username string) error {
+	return adminsClient.DeleteById(username)
+}
+
+func (adminsClient *adminsClient) DeleteById(id string) error {
+	r, body, errs := newDelete(adminsClient.config, AdminsPath+id).End()
+	if errs != nil {
+		return fmt.Errorf("could not delete admin, result: %v error: %v", r, errs)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	return nil
+}
+
+// GenerateRegisterUrl generates a new registration token for an invited admin, returning it with the kong manager
+// url the admin registers with.
+func (adminsClient *adminsClient) GenerateRegisterUrl(usernameOrId string) (*AdminRegistration, error) {
+	r, body, errs := newGet(adminsClient.config, AdminsPath+usernameOrId).Query("generate_register_url=true").End()
+	if errs != nil {
+		return nil, fmt.Errorf("could not generate admin register url, error: %v", errs)
+	}
+
+	if r.StatusCode == 400 {
+		return nil, fmt.Errorf("bad request, message from kong: %s", body)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	registration := &AdminRegistration{}
+	err := json.Unmarshal([]byte(body), registration)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse admin register url response, error: %v", err)
+	}
+
+	if registration.Id == "" {
+		return nil, nil
+	}
+
+	if registration.Token == "" {
+		return nil, fmt.Errorf("could not generate admin register url, error: %v", body)
+	}
+
+	return registration, nil
+}
+
+// Register completes the registration of an invited admin with the token from its invitation and a password. Kong
+// only accepts registrations when kong manager uses basic-auth.
+func (adminsClient *adminsClient) Register(registerRequest *AdminRegisterRequest) error {
+	r, body, errs := newPost(adminsClient.config, AdminsPath+"register").Send(registerRequest).End()
+	if errs != nil {
+		return fmt.Errorf("could not register admin, error: %v", errs)
+	}
+
+	if r.StatusCode == 400 {
+		return fmt.Errorf("bad request, message from kong: %s", body)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	if r.StatusCode != 201 && r.StatusCode != 200 {
+		return fmt.Errorf("could not register admin, error: %v", body)
+	}
+
+	return nil
+}
+
+func (adminsClient *adminsClient) GetRoles(usernameOrId string) ([]*RBACRole, error) {
+	r, body, errs := newGet(adminsClient.config, AdminsPath+usernameOrId+"/roles").End()
+	if errs != nil {
+		return nil, fmt.Errorf("could not get admin roles, error: %v", errs)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	if r.StatusCode == 404 {
+		return nil, nil
+	}
+
+	adminRoles := &AdminRoles{}
+	err := json.Unmarshal([]byte(body), adminRoles)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse admin roles response, error: %v", err)
+	}
+
+	return adminRoles.Roles, nil
+}
+
+func (adminsClient *adminsClient) AddRoles(usernameOrId string, roles []string) ([]*RBACRole, error) {
+	rolesRequest := &AdminRolesRequest{Roles: strings.Join(roles, ",")}
+
+	r, body, errs := newPost(adminsClient.config, AdminsPath+usernameOrId+"/roles").Send(rolesRequest).End()
+	if errs != nil {
+		return nil, fmt.Errorf("could not add roles to admin, error: %v", errs)
+	}
+
+	if r.StatusCode == 400 {
+		return nil, fmt.Errorf("bad request, message from kong: %s", body)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	if r.StatusCode == 404 {
+		return nil, fmt.Errorf("could not add roles to admin, error: %v", body)
+	}
+
+	adminRoles := &AdminRoles{}
+	err := json.Unmarshal([]byte(body), adminRoles)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse admin roles response, error: %v", err)
+	}
+
+	return adminRoles.Roles, nil
+}
+
+func (adminsClient *adminsClient) DeleteRoles(usernameOrId string, roles []string) error {
+	rolesRequest := &AdminRolesRequest{Roles: strings.Join(roles, ",")}
+
+	r, body, errs := newDelete(adminsClient.config, AdminsPath+usernameOrId+"/roles").Send(rolesRequest).End()
+	if errs != nil {
+		return fmt.Errorf("could not delete roles from admin, error: %v", errs)
+	}
+
+	if r.StatusCode == 400 {
+		return fmt.Errorf("bad request, message from kong: %s", body)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	return nil
+}
+
+// GetWorkspaces returns the workspaces an admin has roles in.
+func (adminsClient *adminsClient) GetWorkspaces(usernameOrId string) ([]*Workspace, error) {
+	r, body, errs := newGet(adminsClient.config, AdminsPath+usernameOrId+"/workspaces").End()
+	if errs != nil {
+		return nil, fmt.Errorf("could not get admin workspaces, error: %v", errs)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	if r.StatusCode == 404 {
+		return nil, nil
+	}
+
+	workspaces := make([]*Workspace, 0)
+	err := json.Unmarshal([]byte(body), &workspaces)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse admin workspaces response, error: %v", err)
+	}
+
+	return workspaces, nil
+}
@@ -0,0 +1,32 @@
+package gokong
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AdminsListFollowsPagesFromACopyOfTheQuery(t *testing.T) {
+	client, queries := pagedClient(
+		`{"data":[{"id":"1"}],"next":"/admins?offset=page-2","offset":"page-2"}`,
+		`{"data":[{"id":"2"}],"next":"/admins?offset=page-3"}`,
+	)
+	query := &AdminQueryString{Size: 10}
+
+	admins, err := client.Admins().List(query)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []*Admin{{Id: "1"}, {Id: "2"}}, admins)
+	assert.Equal(t, []string{"size=100", "offset=page-2&size=100"}, *queries)
+	assert.Equal(t, &AdminQueryString{Size: 10}, query)
+}
+
+func Test_AdminsListAcceptsANilQuery(t *testing.T) {
+	client, queries := pagedClient(`{"data":[{"id":"1"}]}`)
+
+	admins, err := client.Admins().List(nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []*Admin{{Id: "1"}}, admins)
+	assert.Equal(t, []string{"size=100"}, *queries)
+}
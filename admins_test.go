@@ -0,0 +1,125 @@
+// +build all enterprise
+
+package gokong
+
+import (
+	"fmt"
+	"testing"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// adminsVersions are the kong enterprise versions with kong manager admins.
+const adminsVersions = ">= 0.34"
+
+func Test_AdminsClient_Invite(t *testing.T) {
+	kongTestContext.SkipUnlessVersion(t, adminsVersions)
+
+	client := NewClient(NewDefaultConfig())
+	username := fmt.Sprintf("admin-%s", uuid.NewV4().String())
+	adminRequest := &AdminRequest{
+		Username:         username,
+		CustomId:         uuid.NewV4().String(),
+		Email:            fmt.Sprintf("%s@example.com", username),
+		RbacTokenEnabled: Bool(true),
+	}
+
+	createdAdmin, err := client.Admins().Invite(adminRequest)
+	assert.Nil(t, err)
+	assert.NotNil(t, createdAdmin)
+	assert.Equal(t, adminRequest.Username, createdAdmin.Username)
+	assert.Equal(t, adminRequest.Email, createdAdmin.Email)
+	assert.True(t, createdAdmin.RbacTokenEnabled)
+
+	result, err := client.Admins().GetByUsername(username)
+	assert.Nil(t, err)
+	assert.Equal(t, createdAdmin.Id, result.Id)
+
+	result, err = client.Admins().GetById(createdAdmin.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, username, result.Username)
+
+	admins, err := client.Admins().List(&AdminQueryString{})
+	assert.Nil(t, err)
+	assert.Contains(t, admins, result)
+
+	updatedAdmin, err := client.Admins().UpdateById(createdAdmin.Id, &AdminRequest{CustomId: "updated"})
+	assert.Nil(t, err)
+	assert.Equal(t, "updated", updatedAdmin.CustomId)
+
+	err = client.Admins().DeleteByUsername(username)
+	assert.Nil(t, err)
+
+	result, err = client.Admins().GetById(createdAdmin.Id)
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+}
+
+func Test_AdminsClient_Roles(t *testing.T) {
+	kongTestContext.SkipUnlessVersion(t, adminsVersions)
+
+	client := NewClient(NewDefaultConfig())
+	username := fmt.Sprintf("admin-%s", uuid.NewV4().String())
+	createdAdmin, err := client.Admins().Invite(&AdminRequest{
+		Username: username,
+		Email:    fmt.Sprintf("%s@example.com", username),
+	})
+	assert.Nil(t, err)
+	createdRole, err := client.RBAC().CreateRole(&RBACRoleRequest{Name: fmt.Sprintf("role-%s", uuid.NewV4().String())})
+	assert.Nil(t, err)
+
+	roles, err := client.Admins().AddRoles(username, []string{createdRole.Name})
+	assert.Nil(t, err)
+	assert.Len(t, roles, 1)
+	assert.Equal(t, createdRole.Id, roles[0].Id)
+
+	roles, err = client.Admins().GetRoles(createdAdmin.Id)
+	assert.Nil(t, err)
+	assert.Len(t, roles, 1)
+
+	workspaces, err := client.Admins().GetWorkspaces(username)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, workspaces)
+
+	err = client.Admins().DeleteRoles(username, []string{createdRole.Name})
+	assert.Nil(t, err)
+
+	roles, err = client.Admins().GetRoles(username)
+	assert.Nil(t, err)
+	assert.Len(t, roles, 0)
+
+	assert.Nil(t, client.RBAC().DeleteRole(createdRole.Id))
+	assert.Nil(t, client.Admins().DeleteById(createdAdmin.Id))
+}
+
+func Test_AdminsClient_GenerateRegisterUrl(t *testing.T) {
+	kongTestContext.SkipUnlessVersion(t, adminsVersions)
+
+	client := NewClient(NewDefaultConfig())
+	username := fmt.Sprintf("admin-%s", uuid.NewV4().String())
+	createdAdmin, err := client.Admins().Invite(&AdminRequest{
+		Username: username,
+		Email:    fmt.Sprintf("%s@example.com", username),
+	})
+	assert.Nil(t, err)
+
+	registration, err := client.Admins().GenerateRegisterUrl(username)
+	assert.Nil(t, err)
+	assert.NotNil(t, registration)
+	assert.Equal(t, createdAdmin.Id, registration.Id)
+	assert.NotEmpty(t, registration.Token)
+	assert.Contains(t, registration.RegisterUrl, registration.Token)
+
+	assert.Nil(t, client.Admins().DeleteById(createdAdmin.Id))
+}
+
+func Test_AdminsClient_GetNonExistent(t *testing.T) {
+	kongTestContext.SkipUnlessVersion(t, adminsVersions)
+
+	client := NewClient(NewDefaultConfig())
+	result, err := client.Admins().GetById(uuid.NewV4().String())
+
+	assert.Nil(t, result)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,108 @@
+package gokong
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// BulkOptions configures the CreateMany, UpdateMany and DeleteMany operations of the entity clients.
+type BulkOptions struct {
+	// Concurrency is the number of requests sent at once, 4 when not set. The limiter of the config still applies.
+	Concurrency int
+	// StopOnError stops sending requests after the first failure, the items that were not sent fail with
+	// ErrBulkSkipped.
+	StopOnError bool
+}
+
+const defaultBulkConcurrency = 4
+
+// ErrBulkSkipped is the error of the items of a bulk operation that were not sent because an earlier item failed.
+var ErrBulkSkipped = errors.New("skipped after an earlier error")
+
+// BulkError is returned by the bulk operations when any of the items failed. Errors has an error for every item, in
+// the order of the input, which is nil for the items that succeeded.
+type BulkError struct {
+	Errors []error
+}
+
+func (bulkError *BulkError) Error() string {
+	failed := 0
+	var first error
+	for _, err := range bulkError.Errors {
+		if err != nil && err != ErrBulkSkipped {
+			if first == nil {
+				first = err
+			}
+			failed++
+		}
+	}
+	return fmt.Sprintf("%d of %d items failed, first error: %v", failed, len(bulkError.Errors), first)
+}
+
+// runBulk calls do for the items 0 to count-1 with a bounded number of workers, stopping when the context of the
+// config is done.
+func runBulk(config *Config, count int, options *BulkOptions, do func(i int) error) error {
+	if options == nil {
+		options = &BulkOptions{}
+	}
+	concurrency := options.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultBulkConcurrency
+	}
+	if concurrency > count {
+		concurrency = count
+	}
+
+	errs := make([]error, count)
+	indexes := make(chan int)
+	var mu sync.Mutex
+	stopped := false
+	var wg sync.WaitGroup
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				mu.Lock()
+				skip := stopped
+				mu.Unlock()
+				if skip {
+					errs[i] = ErrBulkSkipped
+					continue
+				}
+				if err := config.context().Err(); err != nil {
+					errs[i] = err
+					continue
+				}
+
+				if errs[i] = do(i); errs[i] != nil && options.StopOnError {
+					mu.Lock()
+					stopped = true
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < count; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return &BulkError{Errors: errs}
+		}
+	}
+	return nil
+}
+
+func checkBulkUpdate(ids int, requests int) error {
+	if ids != requests {
+		return fmt.Errorf("could not update, got %d ids for %d requests", ids, requests)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package gokong
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// consumerTransport creates consumers with the username as id, failing for the username fail.
+func consumerTransport(sent *[]string, mu *sync.Mutex) roundTripperFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		consumerRequest := &ConsumerRequest{}
+		body, _ := ioutil.ReadAll(req.Body)
+		_ = json.Unmarshal(body, consumerRequest)
+		mu.Lock()
+		*sent = append(*sent, consumerRequest.Username)
+		mu.Unlock()
+
+		response := `{"message": "invalid consumer"}`
+		status := 400
+		if consumerRequest.Username != "fail" {
+			response = `{"id": "` + consumerRequest.Username + `", "username": "` + consumerRequest.Username + `"}`
+			status = 201
+		}
+		return &http.Response{StatusCode: status, Header: http.Header{}, Body: ioutil.NopCloser(bytes.NewBufferString(response))}, nil
+	}
+}
+
+func Test_CreateManyReturnsResultsAndErrorsInOrder(t *testing.T) {
+	var mu sync.Mutex
+	sent := make([]string, 0)
+	client := NewClient(newLimitedConfig(nil, consumerTransport(&sent, &mu)))
+
+	consumers, err := client.Consumers().CreateMany([]*ConsumerRequest{
+		{Username: "a"}, {Username: "fail"}, {Username: "c"}, {Username: "d"},
+	}, &BulkOptions{Concurrency: 3})
+
+	assert.Len(t, sent, 4)
+	assert.Equal(t, "a", consumers[0].Id)
+	assert.Nil(t, consumers[1])
+	assert.Equal(t, "c", consumers[2].Id)
+	assert.Equal(t, "d", consumers[3].Id)
+
+	bulkError, ok := err.(*BulkError)
+	assert.True(t, ok)
+	assert.Len(t, bulkError.Errors, 4)
+	assert.Nil(t, bulkError.Errors[0])
+	assert.Contains(t, bulkError.Errors[1].Error(), "invalid consumer")
+	assert.Nil(t, bulkError.Errors[2])
+	assert.Nil(t, bulkError.Errors[3])
+	assert.Contains(t, err.Error(), "1 of 4 items failed")
+}
+
+func Test_CreateManyStopsOnError(t *testing.T) {
+	var mu sync.Mutex
+	sent := make([]string, 0)
+	client := NewClient(newLimitedConfig(nil, consumerTransport(&sent, &mu)))
+
+	consumers, err := client.Consumers().CreateMany([]*ConsumerRequest{
+		{Username: "a"}, {Username: "fail"}, {Username: "c"},
+	}, &BulkOptions{Concurrency: 1, StopOnError: true})
+
+	assert.Equal(t, []string{"a", "fail"}, sent)
+	assert.Equal(t, "a", consumers[0].Id)
+	assert.Nil(t, consumers[2])
+	bulkError := err.(*BulkError)
+	assert.NotNil(t, bulkError.Errors[1])
+	assert.Equal(t, ErrBulkSkipped, bulkError.Errors[2])
+}
+
+func Test_DeleteManyLimitsConcurrency(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	deleted := make(map[string]bool)
+	client := NewClient(newLimitedConfig(nil, func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		deleted[req.URL.Path] = true
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		return &http.Response{StatusCode: 204, Header: http.Header{}, Body: ioutil.NopCloser(bytes.NewBufferString(""))}, nil
+	}))
+
+	err := client.Plugins().DeleteMany([]string{"1", "2", "3", "4", "5", "6"}, &BulkOptions{Concurrency: 2})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, maxInFlight)
+	assert.Len(t, deleted, 6)
+	assert.True(t, deleted["/plugins/6"])
+}
+
+func Test_UpdateManyRequiresARequestPerId(t *testing.T) {
+	client := NewClient(newLimitedConfig(nil, func(req *http.Request) (*http.Response, error) {
+		t.Fatal("no request expected")
+		return nil, nil
+	}))
+
+	_, err := client.Consumers().UpdateMany([]string{"a", "b"}, []*ConsumerRequest{{Username: "a"}}, nil)
+
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "got 2 ids for 1 requests")
+}
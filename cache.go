@@ -0,0 +1,261 @@
+package gokong
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache keeps the responses to the get and list requests of a client for a time to live, so that repeated reads of
+// the same entities are not sent to kong. Like the limiter, the cache is shared by the clients created from the same
+// config, and entries are kept per workspace and per credentials, so that clients with different permissions never
+// share responses. Only plain reads of entities are cached: status, health, event hook pings and admin register urls
+// are sent to kong every time.
+//
+// A write sent through a client with the cache invalidates the cached responses of the workspace that mention the
+// written entity type, e.g. updating a route invalidates the routes and the routes of a service, and a delete
+// invalidates the whole workspace as kong deletes the plugins and targets of deleted entities. Writes made by other
+// clients or in kong manager are seen once the cached responses expire.
+type Cache struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	entries   map[string]*cacheEntry
+	nextSweep time.Time
+	hits      uint64
+	misses    uint64
+}
+
+// CacheStats are the counters of a cache, Entries includes expired entries that have not been removed yet.
+type CacheStats struct {
+	Hits    uint64
+	Misses  uint64
+	Entries int
+}
+
+type cacheEntry struct {
+	workspace string
+	entities  []string
+	status    int
+	header    http.Header
+	body      string
+	expires   time.Time
+}
+
+// NewCache creates a cache that keeps responses for ttl.
+func NewCache(ttl time.Duration) (*Cache, error) {
+	if ttl <= 0 {
+		return nil, fmt.Errorf("cache ttl must be positive, got %v", ttl)
+	}
+	return &Cache{ttl: ttl, entries: make(map[string]*cacheEntry)}, nil
+}
+
+// Stats returns the hits and misses of the cache since it was created.
+func (cache *Cache) Stats() CacheStats {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	return CacheStats{Hits: cache.hits, Misses: cache.misses, Entries: len(cache.entries)}
+}
+
+// Clear removes all the cached responses, e.g. after kong was changed by another client.
+func (cache *Cache) Clear() {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	cache.entries = make(map[string]*cacheEntry)
+}
+
+// get returns the cached response to a request, it is nil-safe so requests can call it when no cache is configured.
+func (cache *Cache) get(path string, req *http.Request) (*http.Response, string, bool) {
+	if cache == nil || !isCacheable(path, req) {
+		return nil, "", false
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	key := cacheKey(req)
+	entry, ok := cache.entries[key]
+	if ok && time.Now().After(entry.expires) {
+		delete(cache.entries, key)
+		ok = false
+	}
+	if !ok {
+		cache.misses++
+		return nil, "", false
+	}
+	cache.hits++
+
+	resp := &http.Response{
+		Status:     fmt.Sprintf("%d %s", entry.status, http.StatusText(entry.status)),
+		StatusCode: entry.status,
+		Header:     cloneHeader(entry.header),
+		Body:       ioutil.NopCloser(strings.NewReader(entry.body)),
+		Request:    req,
+	}
+	return resp, entry.body, true
+}
+
+// update stores the response to a get or list request and invalidates the responses a write may have changed. resp
+// is nil when the request failed, a failed write may still have been applied by kong.
+func (cache *Cache) update(workspace string, path string, req *http.Request, resp *http.Response, body string) {
+	if cache == nil {
+		return
+	}
+
+	// requests to the workspaces themselves are sent outside of the workspace of the client
+	if !strings.HasPrefix(req.URL.Path, "/"+workspace+"/") {
+		workspace = ""
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	now := time.Now()
+	if now.After(cache.nextSweep) {
+		cache.sweep(now)
+	}
+
+	if req.Method != http.MethodGet {
+		cache.invalidate(workspace, req.Method, pathEntities(path))
+		return
+	}
+
+	// not found is cached too, reading an entity that does not exist is as common as reading one that does
+	if !isCacheable(path, req) || resp == nil || (resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound) {
+		return
+	}
+	cache.entries[cacheKey(req)] = &cacheEntry{
+		workspace: workspace,
+		entities:  pathEntities(path),
+		status:    resp.StatusCode,
+		header:    cloneHeader(resp.Header),
+		body:      body,
+		expires:   now.Add(cache.ttl),
+	}
+}
+
+func (cache *Cache) invalidate(workspace string, method string, entities []string) {
+	for key, entry := range cache.entries {
+		if entry.workspace != workspace {
+			continue
+		}
+		if method == http.MethodDelete || sharesEntity(entry.entities, entities) {
+			delete(cache.entries, key)
+		}
+	}
+}
+
+func (cache *Cache) sweep(now time.Time) {
+	for key, entry := range cache.entries {
+		if now.After(entry.expires) {
+			delete(cache.entries, key)
+		}
+	}
+	cache.nextSweep = now.Add(cache.ttl)
+}
+
+// cacheablePaths are the reads whose responses can be cached, a "*" segment stands for the name or id of an entity
+// and a trailing "**" for the rest of the path.
+var cacheablePaths = []string{
+	"/admins", "/admins/*", "/admins/*/roles", "/admins/*/workspaces",
+	"/certificates", "/certificates/*",
+	"/consumers", "/consumers/*", "/consumers/*/*", "/consumers/*/*/*",
+	"/event-hooks", "/event-hooks/*",
+	"/plugins", "/plugins/*",
+	"/rbac/roles", "/rbac/roles/*", "/rbac/roles/*/endpoints", "/rbac/roles/*/endpoints/**",
+	"/rbac/roles/*/entities", "/rbac/roles/*/entities/*",
+	"/rbac/users", "/rbac/users/*", "/rbac/users/*/roles",
+	"/routes", "/routes/*", "/routes/*/plugins",
+	"/services", "/services/*", "/services/*/plugins", "/services/*/routes",
+	"/snis", "/snis/*",
+	"/upstreams", "/upstreams/*", "/upstreams/*/targets", "/upstreams/*/targets/all",
+	"/workspaces", "/workspaces/*", "/workspaces/*/entities",
+}
+
+// cacheableQueries are the query parameters of the cacheable reads, the others change what kong does, e.g.
+// generate_register_url creates a new registration token.
+var cacheableQueries = map[string]bool{"entity_type": true, "offset": true, "size": true}
+
+// isCacheable tells whether the response to a request can be cached, i.e. the request is a get of a path in
+// cacheablePaths without other query parameters than the cacheable ones.
+func isCacheable(path string, req *http.Request) bool {
+	if req.Method != http.MethodGet {
+		return false
+	}
+	for name := range req.URL.Query() {
+		if !cacheableQueries[name] {
+			return false
+		}
+	}
+	if i := strings.Index(path, "?"); i >= 0 {
+		path = path[:i]
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for _, cacheablePath := range cacheablePaths {
+		if matchesPath(segments, strings.Split(strings.Trim(cacheablePath, "/"), "/")) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesPath(segments []string, pattern []string) bool {
+	for i, segment := range pattern {
+		if segment == "**" {
+			return len(segments) > i
+		}
+		if i >= len(segments) || (segment != "*" && segment != segments[i]) {
+			return false
+		}
+	}
+	return len(segments) == len(pattern)
+}
+
+// cacheKey identifies a request by its url and the credentials it is sent with. The credentials are hashed so that
+// the cache does not keep them.
+func cacheKey(req *http.Request) string {
+	hash := sha256.New()
+	for _, name := range redactedHeaders {
+		for _, value := range req.Header[http.CanonicalHeaderKey(name)] {
+			fmt.Fprintf(hash, "%s: %s\n", name, value)
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil)) + " " + req.URL.String()
+}
+
+// pathEntities returns the entity types a path refers to, e.g. services and routes for /services/example/routes.
+// Entity types are singular so that /routes/example/service refers to services.
+func pathEntities(path string) []string {
+	if i := strings.Index(path, "?"); i >= 0 {
+		path = path[:i]
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	entities := make([]string, 0, (len(segments)+1)/2)
+	for i := 0; i < len(segments); i += 2 {
+		entities = append(entities, strings.TrimSuffix(segments[i], "s"))
+	}
+	return entities
+}
+
+func sharesEntity(entities []string, others []string) bool {
+	for _, entity := range entities {
+		for _, other := range others {
+			if entity == other {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func cloneHeader(header http.Header) http.Header {
+	clone := make(http.Header, len(header))
+	for name, values := range header {
+		clone[name] = append([]string(nil), values...)
+	}
+	return clone
+}
@@ -0,0 +1,156 @@
+package gokong
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingClient returns a client with the cache whose transport counts the requests sent to each path.
+func countingClient(cache *Cache) (*kongAdminClient, func(path string) int) {
+	var mu sync.Mutex
+	sent := make(map[string]int)
+	client := NewClient(&Config{
+		HostAddress: "http://kong:8001",
+		Cache:       cache,
+		HTTPClient: &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			sent[req.Method+" "+req.URL.Path]++
+			mu.Unlock()
+			body := `{"id": "6d1b6b5e-6a3d-4b9a-8d3e-0c0b5a4d6f1e", "name": "example"}`
+			return &http.Response{StatusCode: 200, Header: http.Header{}, Body: ioutil.NopCloser(bytes.NewBufferString(body))}, nil
+		})},
+	})
+	return client, func(path string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return sent[path]
+	}
+}
+
+func newTestCache(t *testing.T, ttl time.Duration) *Cache {
+	cache, err := NewCache(ttl)
+	assert.Nil(t, err)
+	return cache
+}
+
+func Test_CacheServesRepeatedReads(t *testing.T) {
+	cache := newTestCache(t, time.Minute)
+	client, sent := countingClient(cache)
+
+	for i := 0; i < 3; i++ {
+		service, err := client.Services().GetServiceByName("example")
+		assert.Nil(t, err)
+		assert.Equal(t, "example", *service.Name)
+	}
+
+	assert.Equal(t, 1, sent("GET /services/example"))
+	assert.Equal(t, CacheStats{Hits: 2, Misses: 1, Entries: 1}, cache.Stats())
+}
+
+func Test_CacheInvalidatesOnWritesToTheEntityType(t *testing.T) {
+	cache := newTestCache(t, time.Minute)
+	client, sent := countingClient(cache)
+	_, _ = client.Services().GetServiceByName("example")
+	_, _ = client.Routes().GetByName("example")
+	_, _ = client.Routes().GetRoutesFromServiceName("example")
+
+	_, err := client.Routes().UpdateByName("example", &RouteRequest{})
+	assert.Nil(t, err)
+	_, _ = client.Services().GetServiceByName("example")
+	_, _ = client.Routes().GetByName("example")
+	_, _ = client.Routes().GetRoutesFromServiceName("example")
+
+	assert.Equal(t, 1, sent("GET /services/example"))
+	assert.Equal(t, 2, sent("GET /routes/example"))
+	assert.Equal(t, 2, sent("GET /services/example/routes"))
+}
+
+func Test_CacheInvalidatesWorkspaceOnDelete(t *testing.T) {
+	cache := newTestCache(t, time.Minute)
+	client, sent := countingClient(cache)
+	_, _ = client.Services().GetServiceByName("example")
+	_, _ = client.Plugins().GetById("example")
+
+	_ = client.Routes().DeleteByName("example")
+	_, _ = client.Services().GetServiceByName("example")
+	_, _ = client.Plugins().GetById("example")
+
+	assert.Equal(t, 2, sent("GET /services/example"))
+	assert.Equal(t, 2, sent("GET /plugins/example"))
+}
+
+func Test_CacheKeepsWorkspacesApart(t *testing.T) {
+	cache := newTestCache(t, time.Minute)
+	client, sent := countingClient(cache)
+	_, _ = client.InWorkspace("team-a").Services().GetServiceByName("example")
+	_, _ = client.InWorkspace("team-b").Services().GetServiceByName("example")
+
+	_, _ = client.InWorkspace("team-a").Services().UpdateServiceByName("example", &ServiceRequest{})
+	_, _ = client.InWorkspace("team-a").Services().GetServiceByName("example")
+	_, _ = client.InWorkspace("team-b").Services().GetServiceByName("example")
+
+	assert.Equal(t, 2, sent("GET /team-a/services/example"))
+	assert.Equal(t, 1, sent("GET /team-b/services/example"))
+}
+
+func Test_CacheExpiresEntries(t *testing.T) {
+	cache := newTestCache(t, 20*time.Millisecond)
+	client, sent := countingClient(cache)
+	_, _ = client.Services().GetServiceByName("example")
+
+	time.Sleep(30 * time.Millisecond)
+	_, _ = client.Services().GetServiceByName("example")
+
+	assert.Equal(t, 2, sent("GET /services/example"))
+}
+
+func Test_CacheDoesNotCacheStatus(t *testing.T) {
+	cache := newTestCache(t, time.Minute)
+	client, sent := countingClient(cache)
+	_, _ = client.Status().Get()
+	_, _ = client.Status().Get()
+
+	assert.Equal(t, 2, sent("GET /status"))
+	assert.Equal(t, 0, cache.Stats().Entries)
+}
+
+func Test_CacheKeepsCredentialsApart(t *testing.T) {
+	cache := newTestCache(t, time.Minute)
+	client, sent := countingClient(cache)
+	config := *client.config
+	config.AdminToken = "other-token"
+	other := NewClient(&config)
+
+	_, _ = client.Services().GetServiceByName("example")
+	_, _ = other.Services().GetServiceByName("example")
+	_, _ = other.Services().GetServiceByName("example")
+
+	assert.Equal(t, 2, sent("GET /services/example"))
+	assert.Equal(t, 2, cache.Stats().Entries)
+}
+
+func Test_CacheDoesNotCacheReadsWithSideEffects(t *testing.T) {
+	cache := newTestCache(t, time.Minute)
+	client, sent := countingClient(cache)
+	for i := 0; i < 2; i++ {
+		_, _ = client.EventHooks().Ping("example")
+		_, _ = client.Admins().GenerateRegisterUrl("example")
+		_, _ = client.Upstreams().GetHealthById("example")
+	}
+
+	assert.Equal(t, 2, sent("GET /event-hooks/example/ping"))
+	assert.Equal(t, 2, sent("GET /admins/example"))
+	assert.Equal(t, 2, sent("GET /upstreams/example/health"))
+	assert.Equal(t, 0, cache.Stats().Entries)
+}
+
+func Test_NewCacheRequiresPositiveTtl(t *testing.T) {
+	_, err := NewCache(0)
+	assert.NotNil(t, err)
+}
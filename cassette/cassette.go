@@ -0,0 +1,72 @@
+// Package cassette records the requests sent to the kong admin api and their responses to a file, and replays them
+// later so tests of code that uses gokong can run without kong.
+//
+// Install a Recorder as the http client of the gokong config:
+//
+//	recorder, err := cassette.New("testdata/consumers.yaml", cassette.ModeReplayOrRecord)
+//	defer recorder.Stop()
+//
+//	client := gokong.NewClient(&gokong.Config{HostAddress: address, HTTPClient: recorder.Client()})
+package cassette
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Cassette holds the interactions recorded with the admin api.
+type Cassette struct {
+	Interactions []*Interaction `yaml:"interactions"`
+}
+
+// Interaction is a request sent to the admin api together with the response it got.
+type Interaction struct {
+	Request  *Request  `yaml:"request"`
+	Response *Response `yaml:"response"`
+}
+
+type Request struct {
+	Method  string      `yaml:"method"`
+	Url     string      `yaml:"url"`
+	Headers http.Header `yaml:"headers,omitempty"`
+	Body    string      `yaml:"body,omitempty"`
+}
+
+type Response struct {
+	StatusCode int         `yaml:"status_code"`
+	Headers    http.Header `yaml:"headers,omitempty"`
+	Body       string      `yaml:"body,omitempty"`
+}
+
+// Load reads a cassette from a file.
+func Load(path string) (*Cassette, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cassette := &Cassette{}
+	if err := yaml.Unmarshal(data, cassette); err != nil {
+		return nil, err
+	}
+
+	return cassette, nil
+}
+
+// Save writes the cassette to a file, creating its directory when it does not exist.
+func (c *Cassette) Save(path string) error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,47 @@
+package cassette
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"reflect"
+)
+
+// Matcher reports whether a request matches a recorded request. The body of the request has already been read.
+type Matcher func(req *http.Request, body string, recorded *Request) bool
+
+// DefaultMatcher matches requests on their method, path, query string and body. The scheme and host are ignored so
+// a cassette recorded against one kong can be replayed against any address, and json bodies are compared by value.
+func DefaultMatcher(req *http.Request, body string, recorded *Request) bool {
+	if req.Method != recorded.Method {
+		return false
+	}
+
+	recordedUrl, err := url.Parse(recorded.Url)
+	if err != nil {
+		return false
+	}
+
+	if req.URL.Path != recordedUrl.Path {
+		return false
+	}
+
+	if !reflect.DeepEqual(req.URL.Query(), recordedUrl.Query()) {
+		return false
+	}
+
+	return bodiesEqual(body, recorded.Body)
+}
+
+func bodiesEqual(body string, recorded string) bool {
+	if body == recorded {
+		return true
+	}
+
+	var value, recordedValue interface{}
+	if json.Unmarshal([]byte(body), &value) != nil || json.Unmarshal([]byte(recorded), &recordedValue) != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(value, recordedValue)
+}
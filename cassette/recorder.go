@@ -0,0 +1,251 @@
+package cassette
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+)
+
+type Mode int
+
+const (
+	// ModeReplay serves every request from the cassette and fails the requests that were not recorded.
+	ModeReplay Mode = iota
+	// ModeRecord sends every request to kong and records it, the cassette is overwritten when the recorder stops.
+	ModeRecord
+	// ModeReplayOrRecord records when the cassette file does not exist yet and replays it otherwise.
+	ModeReplayOrRecord
+)
+
+// redactedHeaders are the request headers carrying credentials, they are never written to a cassette.
+var redactedHeaders = []string{"Authorization", "Apikey", "Kong-Admin-Token"}
+
+// Redactor changes an interaction before it is written to the cassette, e.g. to replace the secrets in the bodies.
+type Redactor func(interaction *Interaction)
+
+// Recorder is a http.RoundTripper that records or replays the interactions of a cassette.
+//
+// Only the credential headers of the requests are removed from the cassette. Request and response bodies are written
+// as they are, and kong returns secrets in them, such as the keys of certificates and the credentials of consumers:
+// use SetRedactor to remove them before committing a cassette.
+type Recorder struct {
+	path      string
+	mode      Mode
+	cassette  *Cassette
+	used      []bool
+	matcher   Matcher
+	redactor  Redactor
+	transport http.RoundTripper
+	mutex     sync.Mutex
+}
+
+// New creates a recorder for the cassette stored in path. In replay mode the cassette must exist.
+func New(path string, mode Mode) (*Recorder, error) {
+	if mode == ModeReplayOrRecord {
+		if _, err := os.Stat(path); err == nil {
+			mode = ModeReplay
+		} else if os.IsNotExist(err) {
+			mode = ModeRecord
+		} else {
+			return nil, err
+		}
+	}
+
+	recorder := &Recorder{
+		path:      path,
+		mode:      mode,
+		cassette:  &Cassette{},
+		matcher:   DefaultMatcher,
+		transport: http.DefaultTransport,
+	}
+
+	if mode == ModeReplay {
+		cassette, err := Load(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not load cassette %s, error: %v", path, err)
+		}
+		recorder.cassette = cassette
+		recorder.used = make([]bool, len(cassette.Interactions))
+	}
+
+	return recorder, nil
+}
+
+// Mode returns the mode the recorder runs in, ModeReplayOrRecord is resolved to ModeReplay or ModeRecord.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// SetMatcher replaces the function used to find the recorded interaction of a request.
+func (r *Recorder) SetMatcher(matcher Matcher) {
+	r.matcher = matcher
+}
+
+// SetRedactor sets the function that changes each recorded interaction before it is written to the cassette. The
+// response returned to the client is not redacted.
+func (r *Recorder) SetRedactor(redactor Redactor) {
+	r.redactor = redactor
+}
+
+// SetTransport replaces the transport used to send the requests to kong while recording.
+func (r *Recorder) SetTransport(transport http.RoundTripper) {
+	r.transport = transport
+}
+
+// Client returns a http client that sends its requests through the recorder.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Stop saves the cassette when recording.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.cassette.Save(r.path)
+}
+
+// RoundTrip serves the request from the cassette when replaying, and otherwise sends it to kong and records the
+// response. Like any http.RoundTripper it does not modify the request.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, outgoing, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == ModeReplay {
+		if outgoing.Body != nil {
+			outgoing.Body.Close()
+		}
+		return r.replay(req, body)
+	}
+
+	return r.record(req, outgoing, body)
+}
+
+func (r *Recorder) replay(req *http.Request, body string) (*http.Response, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || !r.matcher(req, body, interaction.Request) {
+			continue
+		}
+		r.used[i] = true
+		return interaction.Response.toHttp(req), nil
+	}
+
+	return nil, fmt.Errorf("no recorded interaction in cassette %s matches %s %s", r.path, req.Method, req.URL)
+}
+
+// record sends outgoing, the request to send in place of req, to kong and records the exchange.
+func (r *Recorder) record(req *http.Request, outgoing *http.Request, body string) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(outgoing)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := cloneHeader(req.Header)
+	for _, name := range redactedHeaders {
+		headers.Del(name)
+	}
+
+	interaction := &Interaction{
+		Request: &Request{
+			Method:  req.Method,
+			Url:     req.URL.String(),
+			Headers: headers,
+			Body:    body,
+		},
+		Response: &Response{
+			StatusCode: resp.StatusCode,
+			Headers:    cloneHeader(resp.Header),
+			Body:       string(responseBody),
+		},
+	}
+
+	httpResponse := interaction.Response.toHttp(req)
+	if r.redactor != nil {
+		r.redactor(interaction)
+	}
+
+	r.mutex.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mutex.Unlock()
+
+	return httpResponse, nil
+}
+
+func (r *Response) toHttp(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        cloneHeader(r.Headers),
+		Body:          ioutil.NopCloser(bytes.NewBufferString(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
+
+// readRequestBody returns the body of the request and the request to send in its place. The body is read from
+// GetBody when the request has it, so the request is sent as it is, and otherwise from the body itself, so a copy of
+// the request with a new body is sent instead.
+func readRequestBody(req *http.Request) (string, *http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return "", req, nil
+	}
+
+	if req.GetBody != nil {
+		reader, err := req.GetBody()
+		if err != nil {
+			req.Body.Close()
+			return "", nil, err
+		}
+		defer reader.Close()
+
+		body, err := ioutil.ReadAll(reader)
+		if err != nil {
+			req.Body.Close()
+			return "", nil, err
+		}
+		return string(body), req, nil
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return "", nil, err
+	}
+
+	outgoing := req.WithContext(req.Context())
+	outgoing.Body = ioutil.NopCloser(bytes.NewReader(body))
+	outgoing.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(body)), nil
+	}
+	return string(body), outgoing, nil
+}
+
+func cloneHeader(header http.Header) http.Header {
+	clone := make(http.Header, len(header))
+	for name, values := range header {
+		clone[name] = append([]string(nil), values...)
+	}
+	return clone
+}
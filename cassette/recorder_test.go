@@ -0,0 +1,170 @@
+package cassette_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/globocom/gokong"
+	"github.com/globocom/gokong/cassette"
+	"github.com/globocom/gokong/gokongtest"
+	"github.com/stretchr/testify/assert"
+)
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func tempCassette(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "cassette")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return filepath.Join(dir, "testdata", "cassette.yaml"), func() { os.RemoveAll(dir) }
+}
+
+func TestRecorder_RecordsAndReplays(t *testing.T) {
+	path, cleanup := tempCassette(t)
+	defer cleanup()
+
+	server := gokongtest.NewServer()
+	recorder, err := cassette.New(path, cassette.ModeReplayOrRecord)
+	assert.Nil(t, err)
+	assert.Equal(t, cassette.ModeRecord, recorder.Mode())
+
+	client := gokong.NewClient(&gokong.Config{HostAddress: server.URL, AdminToken: "secret", HTTPClient: recorder.Client()})
+	created, err := client.Consumers().Create(&gokong.ConsumerRequest{Username: "user"})
+	assert.Nil(t, err)
+	missing, err := client.Consumers().GetByUsername("other")
+	assert.Nil(t, err)
+	assert.Nil(t, missing)
+
+	assert.Nil(t, recorder.Stop())
+	server.Close()
+
+	data, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	assert.False(t, strings.Contains(string(data), "secret"))
+
+	recorder, err = cassette.New(path, cassette.ModeReplayOrRecord)
+	assert.Nil(t, err)
+	assert.Equal(t, cassette.ModeReplay, recorder.Mode())
+
+	client = gokong.NewClient(&gokong.Config{HostAddress: "http://kong.invalid:8001", HTTPClient: recorder.Client()})
+	replayed, err := client.Consumers().Create(&gokong.ConsumerRequest{Username: "user"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, created, replayed)
+
+	missing, err = client.Consumers().GetByUsername("other")
+
+	assert.Nil(t, err)
+	assert.Nil(t, missing)
+
+	_, err = client.Consumers().GetByUsername("other")
+
+	assert.NotNil(t, err)
+}
+
+func TestRecorder_ReplayFailsWithoutCassette(t *testing.T) {
+	path, cleanup := tempCassette(t)
+	defer cleanup()
+
+	recorder, err := cassette.New(path, cassette.ModeReplay)
+
+	assert.Nil(t, recorder)
+	assert.NotNil(t, err)
+}
+
+func TestRecorder_ReplayMatchesJsonBodiesByValue(t *testing.T) {
+	path, cleanup := tempCassette(t)
+	defer cleanup()
+
+	recorded := &cassette.Cassette{Interactions: []*cassette.Interaction{{
+		Request:  &cassette.Request{Method: "POST", Url: "http://localhost:8001/consumers?size=10", Body: `{"username":"user","custom_id":"1"}`},
+		Response: &cassette.Response{StatusCode: 201, Body: `{"id":"123"}`},
+	}}}
+	assert.Nil(t, recorded.Save(path))
+
+	recorder, err := cassette.New(path, cassette.ModeReplay)
+	assert.Nil(t, err)
+
+	req, _ := http.NewRequest("POST", "http://other:9000/consumers?size=10", strings.NewReader(`{"custom_id": "1", "username": "user"}`))
+	resp, err := recorder.RoundTrip(req)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 201, resp.StatusCode)
+	body, _ := ioutil.ReadAll(resp.Body)
+	assert.Equal(t, `{"id":"123"}`, string(body))
+}
+
+func TestDefaultMatcher(t *testing.T) {
+	recorded := &cassette.Request{Method: "GET", Url: "http://localhost:8001/services?size=100&offset=abc"}
+
+	matching, _ := http.NewRequest("GET", "http://127.0.0.1:32768/services?offset=abc&size=100", nil)
+	otherQuery, _ := http.NewRequest("GET", "http://localhost:8001/services?size=100", nil)
+	otherMethod, _ := http.NewRequest("DELETE", "http://localhost:8001/services?size=100&offset=abc", nil)
+
+	assert.True(t, cassette.DefaultMatcher(matching, "", recorded))
+	assert.False(t, cassette.DefaultMatcher(otherQuery, "", recorded))
+	assert.False(t, cassette.DefaultMatcher(otherMethod, "", recorded))
+}
+
+func TestRecorder_RedactsInteractionsBeforeSaving(t *testing.T) {
+	path, cleanup := tempCassette(t)
+	defer cleanup()
+
+	server := gokongtest.NewServer()
+	defer server.Close()
+	recorder, err := cassette.New(path, cassette.ModeRecord)
+	assert.Nil(t, err)
+	recorder.SetRedactor(func(interaction *cassette.Interaction) {
+		interaction.Request.Body = strings.Replace(interaction.Request.Body, "private-key", "REDACTED", -1)
+		interaction.Response.Body = strings.Replace(interaction.Response.Body, "private-key", "REDACTED", -1)
+	})
+
+	client := gokong.NewClient(&gokong.Config{HostAddress: server.URL, HTTPClient: recorder.Client()})
+	certificate, err := client.Certificates().Create(&gokong.CertificateRequest{Cert: gokong.String("public-cert"), Key: gokong.String("private-key")})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "private-key", *certificate.Key)
+
+	assert.Nil(t, recorder.Stop())
+	data, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	assert.False(t, strings.Contains(string(data), "private-key"))
+	assert.True(t, strings.Contains(string(data), "REDACTED"))
+}
+
+func TestRecorder_DoesNotModifyTheRequest(t *testing.T) {
+	path, cleanup := tempCassette(t)
+	defer cleanup()
+
+	recorder, err := cassette.New(path, cassette.ModeRecord)
+	assert.Nil(t, err)
+	var sentBody string
+	recorder.SetTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		body, _ := ioutil.ReadAll(req.Body)
+		sentBody = string(body)
+		return &http.Response{StatusCode: 201, Header: http.Header{}, Body: ioutil.NopCloser(strings.NewReader(`{"id":"123"}`))}, nil
+	}))
+
+	body := ioutil.NopCloser(strings.NewReader(`{"username":"user"}`))
+	req, _ := http.NewRequest("POST", "http://localhost:8001/consumers", body)
+	resp, err := recorder.RoundTrip(req)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 201, resp.StatusCode)
+	assert.Equal(t, `{"username":"user"}`, sentBody)
+	assert.True(t, req.Body == body)
+	assert.Nil(t, req.GetBody)
+	assert.Nil(t, recorder.Stop())
+	data, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), `{"username":"user"}`)
+}
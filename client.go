@@ -1,11 +1,14 @@
 package gokong
 
 import (
+	"context"
+	"net/http"
 	"net/url"
 	"os"
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
@@ -29,6 +32,11 @@ type KongAdminClient interface {
 	Services() ServiceClient
 	Targets() TargetClient
 	Workspaces() WorkspaceClient
+	RBAC() RBACClient
+	Admins() AdminsClient
+	EventHooks() EventHooksClient
+	InWorkspace(workspace string) KongAdminClient
+	WithContext(ctx context.Context) KongAdminClient
 }
 
 type kongAdminClient struct {
@@ -43,6 +51,31 @@ type Config struct {
 	ApiKey             string
 	AdminToken         string
 	Workspace          string
+	// HTTPClient is used to send the requests to kong when set, e.g. to install a custom transport.
+	HTTPClient *http.Client
+	UserAgent  string
+	// Timeout limits the time of each request when HTTPClient is not set.
+	Timeout time.Duration
+	// Logger receives a debug entry for every request sent to kong when set.
+	Logger Logger
+	// LogRequestBody adds the request bodies to the log entries. They are not redacted and can carry secrets, such as
+	// rbac user tokens, admin passwords and consumer credentials.
+	LogRequestBody bool
+	// Instrumentation is notified of every request sent to kong when set.
+	Instrumentation Instrumentation
+	// Limiter caps the rate and the number of requests in flight when set.
+	Limiter *Limiter
+	// Cache keeps the responses to get and list requests for a time to live when set.
+	Cache *Cache
+
+	ctx context.Context
+}
+
+func (config *Config) context() context.Context {
+	if config.ctx == nil {
+		return context.Background()
+	}
+	return config.ctx
 }
 
 func addQueryString(currentUrl string, filter interface{}) (string, error) {
@@ -167,3 +200,37 @@ func (kongAdminClient *kongAdminClient) Workspaces() WorkspaceClient {
 		config: kongAdminClient.config,
 	}
 }
+
+func (kongAdminClient *kongAdminClient) RBAC() RBACClient {
+	return &rbacClient{
+		config: kongAdminClient.config,
+	}
+}
+
+func (kongAdminClient *kongAdminClient) Admins() AdminsClient {
+	return &adminsClient{
+		config: kongAdminClient.config,
+	}
+}
+
+func (kongAdminClient *kongAdminClient) EventHooks() EventHooksClient {
+	return &eventHooksClient{
+		config: kongAdminClient.config,
+	}
+}
+
+// InWorkspace returns a client bound to a workspace. It copies the config instead of changing it, so clients for
+// different workspaces can be used concurrently, and shares the http client of the config.
+func (kongAdminClient *kongAdminClient) InWorkspace(workspace string) KongAdminClient {
+	config := *kongAdminClient.config
+	config.Workspace = workspace
+	return NewClient(&config)
+}
+
+// WithContext returns a client whose requests use ctx, which cancels them and is passed to the instrumentation of the
+// config, e.g. to link the requests to the trace of the caller. Like InWorkspace the config is copied.
+func (kongAdminClient *kongAdminClient) WithContext(ctx context.Context) KongAdminClient {
+	config := *kongAdminClient.config
+	config.ctx = ctx
+	return NewClient(&config)
+}
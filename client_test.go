@@ -8,6 +8,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/globocom/gokong/containers"
@@ -29,30 +30,33 @@ func Test_Newclient(t *testing.T) {
 	assert.Equal(t, os.Getenv(EnvKongAdminPassword), result.config.Password)
 }
 
-func TestMain(m *testing.M) {
-	testContext := containers.StartKong(
-		GetEnvVarOrDefault("KONG_VERSION", defaultKongVersion),
-		GetEnvVarOrDefault("KONG_DOCKERFILE_PATH", defaultDockerfilePath),
-	)
-
-	err := os.Setenv(EnvKongAdminHostAddress, testContext.KongHostAddress)
-	if err != nil {
-		log.Fatalf("Could not set kong host address env variable: %v", err)
-	}
+// kongTestContext is the kong the tests are currently running against, use it to skip tests by kong version.
+var kongTestContext *containers.TestContext
 
+func TestMain(m *testing.M) {
 	stopSignal := make(chan bool)
 	serverPort, _ := freeport.GetFreePort()
 
-	err = os.Setenv(kong401Server, fmt.Sprintf("http://localhost:%d", serverPort))
+	err := os.Setenv(kong401Server, fmt.Sprintf("http://localhost:%d", serverPort))
 	if err != nil {
 		log.Fatalf("Could not set kong api host address env variable: %v", err)
 	}
 
 	go func() { StartServer(serverPort, stopSignal) }()
 
-	code := m.Run()
-
-	containers.StopKong(testContext)
+	// KONG_VERSION can hold a comma separated list of versions, the tests run against each of them in turn
+	versions := strings.Split(GetEnvVarOrDefault("KONG_VERSION", defaultKongVersion), ",")
+
+	code := containers.RunMatrix(m, versions, containers.StartOptions{
+		DockerfilePath: GetEnvVarOrDefault("KONG_DOCKERFILE_PATH", defaultDockerfilePath),
+		Database:       GetEnvVarOrDefault("KONG_DATABASE", containers.DatabasePostgres),
+	}, func(testContext *containers.TestContext) {
+		kongTestContext = testContext
+		err := os.Setenv(EnvKongAdminHostAddress, testContext.KongHostAddress)
+		if err != nil {
+			log.Fatalf("Could not set kong host address env variable: %v", err)
+		}
+	})
 
 	os.Exit(code)
 }
@@ -0,0 +1,405 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+
+	"github.com/globocom/gokong"
+	"gopkg.in/yaml.v2"
+)
+
+// entity describes how the cli manages a type of entity. Entities that belong to a parent, such as the targets of an
+// upstream, receive the name or id of the parent as their first argument. A nil function means the action is not
+// supported for the entity.
+type entity struct {
+	parent  string
+	columns []string
+	get     func(client gokong.KongAdminClient, parent string, id string) (interface{}, error)
+	list    func(client gokong.KongAdminClient, parent string) (interface{}, error)
+	create  func(client gokong.KongAdminClient, parent string, data []byte) (interface{}, error)
+	update  func(client gokong.KongAdminClient, parent string, id string, data []byte) (interface{}, error)
+	delete  func(client gokong.KongAdminClient, parent string, id string) error
+}
+
+var entities = map[string]*entity{
+	"services": {
+		columns: []string{"id", "name", "protocol", "host", "port", "path"},
+		get: func(client gokong.KongAdminClient, parent string, id string) (interface{}, error) {
+			return client.Services().GetServiceById(id)
+		},
+		list: func(client gokong.KongAdminClient, parent string) (interface{}, error) {
+			return client.Services().GetServices(&gokong.ServiceQueryString{})
+		},
+		create: func(client gokong.KongAdminClient, parent string, data []byte) (interface{}, error) {
+			request := &gokong.ServiceRequest{}
+			if err := decode(data, request); err != nil {
+				return nil, err
+			}
+			return client.Services().Create(request)
+		},
+		update: func(client gokong.KongAdminClient, parent string, id string, data []byte) (interface{}, error) {
+			request := &gokong.ServiceRequest{}
+			if err := decode(data, request); err != nil {
+				return nil, err
+			}
+			return client.Services().UpdateServiceById(id, request)
+		},
+		delete: func(client gokong.KongAdminClient, parent string, id string) error {
+			return client.Services().DeleteServiceById(id)
+		},
+	},
+	"routes": {
+		columns: []string{"id", "name", "protocols", "hosts", "paths", "service"},
+		get: func(client gokong.KongAdminClient, parent string, id string) (interface{}, error) {
+			return client.Routes().GetById(id)
+		},
+		list: func(client gokong.KongAdminClient, parent string) (interface{}, error) {
+			return client.Routes().List(&gokong.RouteQueryString{})
+		},
+		create: func(client gokong.KongAdminClient, parent string, data []byte) (interface{}, error) {
+			request := &gokong.RouteRequest{}
+			if err := decode(data, request); err != nil {
+				return nil, err
+			}
+			return client.Routes().Create(request)
+		},
+		update: func(client gokong.KongAdminClient, parent string, id string, data []byte) (interface{}, error) {
+			request := &gokong.RouteRequest{}
+			if err := decode(data, request); err != nil {
+				return nil, err
+			}
+			return client.Routes().UpdateById(id, request)
+		},
+		delete: func(client gokong.KongAdminClient, parent string, id string) error {
+			return client.Routes().DeleteById(id)
+		},
+	},
+	"consumers": {
+		columns: []string{"id", "username", "custom_id"},
+		get: func(client gokong.KongAdminClient, parent string, id string) (interface{}, error) {
+			return client.Consumers().GetById(id)
+		},
+		list: func(client gokong.KongAdminClient, parent string) (interface{}, error) {
+			return client.Consumers().List(&gokong.ConsumerQueryString{})
+		},
+		create: func(client gokong.KongAdminClient, parent string, data []byte) (interface{}, error) {
+			request := &gokong.ConsumerRequest{}
+			if err := decode(data, request); err != nil {
+				return nil, err
+			}
+			return client.Consumers().Create(request)
+		},
+		update: func(client gokong.KongAdminClient, parent string, id string, data []byte) (interface{}, error) {
+			request := &gokong.ConsumerRequest{}
+			if err := decode(data, request); err != nil {
+				return nil, err
+			}
+			return client.Consumers().UpdateById(id, request)
+		},
+		delete: func(client gokong.KongAdminClient, parent string, id string) error {
+			return client.Consumers().DeleteById(id)
+		},
+	},
+	"plugins": {
+		columns: []string{"id", "name", "enabled", "service", "route", "consumer"},
+		get: func(client gokong.KongAdminClient, parent string, id string) (interface{}, error) {
+			return client.Plugins().GetById(id)
+		},
+		list: func(client gokong.KongAdminClient, parent string) (interface{}, error) {
+			return client.Plugins().List(&gokong.PluginQueryString{})
+		},
+		create: func(client gokong.KongAdminClient, parent string, data []byte) (interface{}, error) {
+			request := &gokong.PluginRequest{}
+			if err := decode(data, request); err != nil {
+				return nil, err
+			}
+			return client.Plugins().Create(request)
+		},
+		update: func(client gokong.KongAdminClient, parent string, id string, data []byte) (interface{}, error) {
+			request := &gokong.PluginRequest{}
+			if err := decode(data, request); err != nil {
+				return nil, err
+			}
+			return client.Plugins().UpdateById(id, request)
+		},
+		delete: func(client gokong.KongAdminClient, parent string, id string) error {
+			return client.Plugins().DeleteById(id)
+		},
+	},
+	"upstreams": {
+		columns: []string{"id", "name", "slots", "hash_on"},
+		get: func(client gokong.KongAdminClient, parent string, id string) (interface{}, error) {
+			return client.Upstreams().GetById(id)
+		},
+		list: func(client gokong.KongAdminClient, parent string) (interface{}, error) {
+			return client.Upstreams().ListWithQuery(&gokong.UpstreamQueryString{})
+		},
+		create: func(client gokong.KongAdminClient, parent string, data []byte) (interface{}, error) {
+			request := &gokong.UpstreamRequest{}
+			if err := decode(data, request); err != nil {
+				return nil, err
+			}
+			return client.Upstreams().Create(request)
+		},
+		update: func(client gokong.KongAdminClient, parent string, id string, data []byte) (interface{}, error) {
+			request := &gokong.UpstreamRequest{}
+			if err := decode(data, request); err != nil {
+				return nil, err
+			}
+			return client.Upstreams().UpdateById(id, request)
+		},
+		delete: func(client gokong.KongAdminClient, parent string, id string) error {
+			return client.Upstreams().DeleteById(id)
+		},
+	},
+	"targets": {
+		parent:  "upstream",
+		columns: []string{"id", "target", "weight", "upstream"},
+		get: func(client gokong.KongAdminClient, parent string, id string) (interface{}, error) {
+			return client.Targets().GetFromUpstreamById(parent, id)
+		},
+		list: func(client gokong.KongAdminClient, parent string) (interface{}, error) {
+			return client.Targets().ListFromUpstream(parent, &gokong.TargetQueryString{})
+		},
+		create: func(client gokong.KongAdminClient, parent string, data []byte) (interface{}, error) {
+			request := &gokong.TargetRequest{}
+			if err := decode(data, request); err != nil {
+				return nil, err
+			}
+			return client.Targets().CreateFromUpstreamId(parent, request)
+		},
+		update: func(client gokong.KongAdminClient, parent string, id string, data []byte) (interface{}, error) {
+			request := &gokong.TargetRequest{}
+			if err := decode(data, request); err != nil {
+				return nil, err
+			}
+			return client.Targets().UpdateFromUpstreamById(parent, id, request)
+		},
+		delete: func(client gokong.KongAdminClient, parent string, id string) error {
+			return client.Targets().DeleteFromUpstreamById(parent, id)
+		},
+	},
+	"certificates": {
+		columns: []string{"id", "tags"},
+		get: func(client gokong.KongAdminClient, parent string, id string) (interface{}, error) {
+			return client.Certificates().GetById(id)
+		},
+		list: func(client gokong.KongAdminClient, parent string) (interface{}, error) {
+			certificates, err := client.Certificates().List()
+			if err != nil {
+				return nil, err
+			}
+			return certificates.Results, nil
+		},
+		create: func(client gokong.KongAdminClient, parent string, data []byte) (interface{}, error) {
+			request := &gokong.CertificateRequest{}
+			if err := decode(data, request); err != nil {
+				return nil, err
+			}
+			return client.Certificates().Create(request)
+		},
+		update: func(client gokong.KongAdminClient, parent string, id string, data []byte) (interface{}, error) {
+			request := &gokong.CertificateRequest{}
+			if err := decode(data, request); err != nil {
+				return nil, err
+			}
+			return client.Certificates().UpdateById(id, request)
+		},
+		delete: func(client gokong.KongAdminClient, parent string, id string) error {
+			return client.Certificates().DeleteById(id)
+		},
+	},
+	"snis": {
+		columns: []string{"name", "certificate"},
+		get: func(client gokong.KongAdminClient, parent string, id string) (interface{}, error) {
+			return client.Snis().GetByName(id)
+		},
+		list: func(client gokong.KongAdminClient, parent string) (interface{}, error) {
+			snis, err := client.Snis().List()
+			if err != nil {
+				return nil, err
+			}
+			return snis.Results, nil
+		},
+		create: func(client gokong.KongAdminClient, parent string, data []byte) (interface{}, error) {
+			request := &gokong.SnisRequest{}
+			if err := decode(data, request); err != nil {
+				return nil, err
+			}
+			return client.Snis().Create(request)
+		},
+		update: func(client gokong.KongAdminClient, parent string, id string, data []byte) (interface{}, error) {
+			request := &gokong.SnisRequest{}
+			if err := decode(data, request); err != nil {
+				return nil, err
+			}
+			return client.Snis().UpdateByName(id, request)
+		},
+		delete: func(client gokong.KongAdminClient, parent string, id string) error {
+			return client.Snis().DeleteByName(id)
+		},
+	},
+	"workspaces": {
+		columns: []string{"id", "name", "comment"},
+		get: func(client gokong.KongAdminClient, parent string, id string) (interface{}, error) {
+			return client.Workspaces().Get(id)
+		},
+		list: func(client gokong.KongAdminClient, parent string) (interface{}, error) {
+			return client.Workspaces().List(&gokong.WorkspaceQueryString{})
+		},
+		create: func(client gokong.KongAdminClient, parent string, data []byte) (interface{}, error) {
+			request := &gokong.WorkspaceRequest{}
+			if err := decode(data, request); err != nil {
+				return nil, err
+			}
+			return client.Workspaces().Create(request)
+		},
+		// the workspace client updates and deletes the workspace of its config
+		update: func(client gokong.KongAdminClient, parent string, id string, data []byte) (interface{}, error) {
+			request := &gokong.WorkspaceRequest{}
+			if err := decode(data, request); err != nil {
+				return nil, err
+			}
+			return client.InWorkspace(id).Workspaces().Update(request)
+		},
+		delete: func(client gokong.KongAdminClient, parent string, id string) error {
+			return client.InWorkspace(id).Workspaces().Delete()
+		},
+	},
+}
+
+func (c *cli) runEntityCommand(name string, action string, args []string) int {
+	e, ok := entities[name]
+	if !ok {
+		fmt.Fprintf(c.stderr, "unknown entity %s\n", name)
+		return 2
+	}
+
+	parent := ""
+	if e.parent != "" {
+		if len(args) == 0 {
+			fmt.Fprintf(c.stderr, "%s %s requires the name or id of the %s\n", name, action, e.parent)
+			return 2
+		}
+		parent, args = args[0], args[1:]
+	}
+
+	expectedArgs := map[string]int{"get": 1, "list": 0, "create": 0, "update": 1, "delete": 1}
+	count, known := expectedArgs[action]
+	if !known || !e.supports(action) {
+		fmt.Fprintf(c.stderr, "%s does not support %s\n", name, action)
+		return 2
+	}
+	if len(args) != count {
+		fmt.Fprintf(c.stderr, "%s %s expects %d argument(s), got %d\n", name, action, count, len(args))
+		return 2
+	}
+
+	var result interface{}
+	var err error
+	switch action {
+	case "get":
+		result, err = e.get(c.client, parent, args[0])
+		if err == nil && isNil(result) {
+			err = fmt.Errorf("%s %s not found", name, args[0])
+		}
+	case "list":
+		result, err = e.list(c.client, parent)
+	case "create", "update":
+		data, readErr := c.readFile()
+		if readErr != nil {
+			return c.fail(readErr)
+		}
+		if action == "create" {
+			result, err = e.create(c.client, parent, data)
+			break
+		}
+		if e.get != nil {
+			if data, err = c.mergeWithCurrent(e, name, parent, args[0], data); err != nil {
+				break
+			}
+		}
+		result, err = e.update(c.client, parent, args[0], data)
+	case "delete":
+		if err = e.delete(c.client, parent, args[0]); err == nil {
+			fmt.Fprintf(c.stdout, "deleted %s %s\n", name, args[0])
+			return 0
+		}
+	}
+	if err != nil {
+		return c.fail(err)
+	}
+
+	if err := c.print(result, e.columns); err != nil {
+		return c.fail(err)
+	}
+	return 0
+}
+
+func (e *entity) supports(action string) bool {
+	switch action {
+	case "get":
+		return e.get != nil
+	case "list":
+		return e.list != nil
+	case "create":
+		return e.create != nil
+	case "update":
+		return e.update != nil
+	case "delete":
+		return e.delete != nil
+	}
+	return false
+}
+
+// mergeWithCurrent sets the fields of the file over the current fields of the entity, so that an update only changes
+// the fields of the file. The request types send every field they have to kong, which resets the fields left empty.
+func (c *cli) mergeWithCurrent(e *entity, name string, parent string, id string, data []byte) ([]byte, error) {
+	current, err := e.get(c.client, parent, id)
+	if err != nil {
+		return nil, err
+	}
+	if isNil(current) {
+		return nil, fmt.Errorf("%s %s not found", name, id)
+	}
+
+	document, err := normalise(current)
+	if err != nil {
+		return nil, err
+	}
+	fields, _ := document.(map[string]interface{})
+
+	var changes interface{}
+	if err := yaml.Unmarshal(data, &changes); err != nil {
+		return nil, fmt.Errorf("could not parse file, error: %v", err)
+	}
+	changedFields, ok := toJsonValue(changes).(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("could not parse file, it must contain an object")
+	}
+	for field, value := range changedFields {
+		fields[field] = value
+	}
+
+	return json.Marshal(fields)
+}
+
+func (c *cli) readFile() ([]byte, error) {
+	if c.file == "" {
+		return nil, fmt.Errorf("the entity must be given in a file with -f")
+	}
+	if c.file == "-" {
+		return ioutil.ReadAll(c.stdin)
+	}
+	return ioutil.ReadFile(c.file)
+}
+
+func isNil(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
@@ -0,0 +1,142 @@
+// Command gokong manages the entities of the kong admin api from the command line.
+//
+//	gokong <entity> <action> [arguments] [flags]
+//
+// The client is configured with the environment variables read by gokong.NewClientWithOptions, e.g. KONG_ADMIN_ADDR or
+// KONG_ADMIN_TOKEN, and the -host and -workspace flags. Entities are created and updated from a json or yaml file:
+//
+//	gokong services list -o json
+//	gokong services create -f service.yaml
+//	gokong targets list my-upstream
+//	gokong routes delete my-route
+//
+// The whole state of kong can also be exported to a file, compared with a file and made to match a file:
+//
+//	gokong dump -f kong.yaml
+//	gokong diff -f kong.yaml
+//	gokong apply -f kong.yaml -select-tag team-a
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/globocom/gokong"
+)
+
+type cli struct {
+	client     gokong.KongAdminClient
+	stdin      io.Reader
+	stdout     io.Writer
+	stderr     io.Writer
+	output     string
+	file       string
+	selectTags []string
+	dryRun     bool
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command and returns its exit code: 0 on success, 1 when the command fails, 2 when it is used
+// incorrectly and exitDrift when diff finds changes.
+func run(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	c := &cli{stdin: stdin, stdout: stdout, stderr: stderr}
+
+	flags := flag.NewFlagSet("gokong", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&c.output, "o", "table", "output format: table, json or yaml")
+	flags.StringVar(&c.file, "f", "", "json or yaml file with the entity to create or update, or the state to dump to, diff or apply, - is stdin or stdout")
+	selectTags := flags.String("select-tag", "", "comma separated tags, dump, diff and apply only manage the entities with all of them")
+	flags.BoolVar(&c.dryRun, "dry-run", false, "apply only shows the changes it would make")
+	host := flags.String("host", "", "address of the kong admin api, defaults to "+gokong.EnvKongAdminHostAddress)
+	workspace := flags.String("workspace", "", "kong enterprise workspace, defaults to "+gokong.EnvKongWorkspace)
+	flags.Usage = func() {
+		usage(stderr, flags)
+	}
+
+	positional, err := parseInterspersed(flags, args)
+	if err == flag.ErrHelp {
+		return 0
+	}
+	if err != nil {
+		return 2
+	}
+
+	if c.output != "table" && c.output != "json" && c.output != "yaml" {
+		fmt.Fprintf(stderr, "unknown output format %s\n", c.output)
+		return 2
+	}
+
+	isStateCommand := len(positional) > 0 && stateCommands[positional[0]]
+	if len(positional) < 2 && !isStateCommand {
+		flags.Usage()
+		return 2
+	}
+
+	if *selectTags != "" {
+		c.selectTags = strings.Split(*selectTags, ",")
+	}
+
+	options := make([]gokong.Option, 0)
+	if *host != "" {
+		options = append(options, gokong.WithHost(*host))
+	}
+	if *workspace != "" {
+		options = append(options, gokong.WithWorkspace(*workspace))
+	}
+	c.client, err = gokong.NewClientWithOptions(options...)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return 2
+	}
+
+	if isStateCommand {
+		return c.runStateCommand(positional[0], positional[1:])
+	}
+	return c.runEntityCommand(positional[0], positional[1], positional[2:])
+}
+
+// parseInterspersed parses the flags wherever they appear among the positional arguments, which the flag package
+// does not do on its own.
+func parseInterspersed(flags *flag.FlagSet, args []string) ([]string, error) {
+	positional := make([]string, 0)
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		if flags.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, flags.Arg(0))
+		args = flags.Args()[1:]
+	}
+}
+
+func usage(w io.Writer, flags *flag.FlagSet) {
+	names := make([]string, 0, len(entities))
+	for name := range entities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(w, "usage: gokong <entity> <action> [arguments] [flags]\n")
+	fmt.Fprintf(w, "       gokong dump|diff|apply [flags]\n\n")
+	fmt.Fprintf(w, "entities: %s\n", strings.Join(names, ", "))
+	fmt.Fprintf(w, "actions:  get <name-or-id>, list, create -f <file>, update <name-or-id> -f <file>, delete <name-or-id>\n")
+	fmt.Fprintf(w, "          targets are managed within an upstream, e.g. gokong targets list <upstream>\n")
+	fmt.Fprintf(w, "state:    dump writes the state of kong to -f, diff shows the changes to make kong match -f and apply makes them,\n")
+	fmt.Fprintf(w, "          diff and apply -dry-run exit with %d when there are changes\n\n", exitDrift)
+	fmt.Fprintf(w, "flags:\n")
+	flags.PrintDefaults()
+}
+
+func (c *cli) fail(err error) int {
+	fmt.Fprintf(c.stderr, "error: %v\n", err)
+	return 1
+}
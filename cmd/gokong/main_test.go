@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/globocom/gokong/gokongtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func runCommand(server *gokongtest.Server, stdin string, args ...string) (int, string, string) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	code := run(append(args, "-host", server.URL), strings.NewReader(stdin), stdout, stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestServices(t *testing.T) {
+	server := gokongtest.NewServer()
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "gokong")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "service.yaml")
+	assert.Nil(t, ioutil.WriteFile(file, []byte("name: service\nprotocol: http\nhost: example.com\nport: 8080\n"), 0644))
+
+	code, stdout, _ := runCommand(server, "", "services", "create", "-f", file, "-o", "json")
+	assert.Equal(t, 0, code)
+	created := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal([]byte(stdout), &created))
+	assert.Equal(t, "service", created["name"])
+	assert.Equal(t, float64(8080), created["port"])
+
+	code, stdout, _ = runCommand(server, `{"host": "example.org"}`, "services", "update", "service", "-f", "-", "-o", "yaml")
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "host: example.org\n")
+
+	code, stdout, _ = runCommand(server, "", "services", "list")
+	assert.Equal(t, 0, code)
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	assert.Len(t, lines, 2)
+	assert.Equal(t, []string{"ID", "NAME", "PROTOCOL", "HOST", "PORT", "PATH"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{created["id"].(string), "service", "http", "example.org", "8080"}, strings.Fields(lines[1]))
+
+	code, stdout, _ = runCommand(server, "", "services", "delete", "service")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "deleted services service\n", stdout)
+
+	code, _, stderr := runCommand(server, "", "services", "get", "service")
+	assert.Equal(t, 1, code)
+	assert.Equal(t, "error: services service not found\n", stderr)
+}
+
+func TestTargetsRequireUpstream(t *testing.T) {
+	server := gokongtest.NewServer()
+	defer server.Close()
+
+	code, _, _ := runCommand(server, "name: upstream\n", "upstreams", "create", "-f", "-")
+	assert.Equal(t, 0, code)
+
+	code, _, stderr := runCommand(server, "", "targets", "list")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, "requires the name or id of the upstream")
+
+	code, _, _ = runCommand(server, "target: example.com:80\nweight: 10\n", "targets", "create", "upstream", "-f", "-")
+	assert.Equal(t, 0, code)
+
+	code, stdout, _ := runCommand(server, "", "targets", "list", "upstream")
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "example.com:80")
+
+	code, stdout, _ = runCommand(server, "", "targets", "get", "upstream", "example.com:80", "-o", "yaml")
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "weight: 10\n")
+
+	// the fake server behaves like the kong versions that do not support updating targets
+	code, _, stderr = runCommand(server, "weight: 20\n", "targets", "update", "upstream", "example.com:80", "-f", "-")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "updating targets is not supported")
+}
+
+func TestUpstreamsListAllPages(t *testing.T) {
+	server := gokongtest.NewServer()
+	defer server.Close()
+
+	for i := 0; i < 120; i++ {
+		code, _, _ := runCommand(server, fmt.Sprintf("name: upstream-%d\n", i), "upstreams", "create", "-f", "-")
+		assert.Equal(t, 0, code)
+	}
+
+	code, stdout, _ := runCommand(server, "", "upstreams", "list")
+	assert.Equal(t, 0, code)
+	assert.Len(t, strings.Split(strings.TrimSpace(stdout), "\n"), 121)
+}
+
+func TestInvalidHost(t *testing.T) {
+	stderr := &bytes.Buffer{}
+	code := run([]string{"services", "list", "-host", "localhost:8001"}, strings.NewReader(""), &bytes.Buffer{}, stderr)
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr.String(), "invalid host address")
+}
+
+func TestUsageErrors(t *testing.T) {
+	server := gokongtest.NewServer()
+	defer server.Close()
+
+	code, _, stderr := runCommand(server, "", "services")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, "usage: gokong")
+
+	code, _, stderr = runCommand(server, "", "services", "list", "-o", "xml")
+	assert.Equal(t, 2, code)
+	assert.Equal(t, "unknown output format xml\n", stderr)
+
+	code, _, stderr = runCommand(server, "", "apis", "list")
+	assert.Equal(t, 2, code)
+	assert.Equal(t, "unknown entity apis\n", stderr)
+
+	code, _, stderr = runCommand(server, "", "routes", "create")
+	assert.Equal(t, 1, code)
+	assert.Equal(t, "error: the entity must be given in a file with -f\n", stderr)
+}
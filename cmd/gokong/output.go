@@ -0,0 +1,137 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v2"
+)
+
+// decode reads a json or yaml document into a request. The document is converted to json first so that the json
+// names of the fields apply to both formats.
+func decode(data []byte, request interface{}) error {
+	var document interface{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return fmt.Errorf("could not parse file, error: %v", err)
+	}
+
+	content, err := json.Marshal(toJsonValue(document))
+	if err != nil {
+		return fmt.Errorf("could not parse file, error: %v", err)
+	}
+
+	if err := json.Unmarshal(content, request); err != nil {
+		return fmt.Errorf("could not parse file, error: %v", err)
+	}
+	return nil
+}
+
+// toJsonValue converts the maps decoded by yaml, which are keyed by interface{}, to maps keyed by string.
+func toJsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			m[fmt.Sprint(key)] = toJsonValue(item)
+		}
+		return m
+	case []interface{}:
+		for i, item := range v {
+			v[i] = toJsonValue(item)
+		}
+	}
+	return value
+}
+
+// normalise converts an entity or a list of entities to the generic values of its json document, so that every
+// output format shows the same fields.
+func normalise(value interface{}) (interface{}, error) {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var document interface{}
+	if err := json.Unmarshal(content, &document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+func (c *cli) print(value interface{}, columns []string) error {
+	document, err := normalise(value)
+	if err != nil {
+		return fmt.Errorf("could not format output, error: %v", err)
+	}
+
+	switch c.output {
+	case "json":
+		content, err := json.MarshalIndent(document, "", "  ")
+		if err != nil {
+			return fmt.Errorf("could not format output, error: %v", err)
+		}
+		fmt.Fprintln(c.stdout, string(content))
+	case "yaml":
+		content, err := yaml.Marshal(document)
+		if err != nil {
+			return fmt.Errorf("could not format output, error: %v", err)
+		}
+		fmt.Fprint(c.stdout, string(content))
+	default:
+		rows, ok := document.([]interface{})
+		if !ok {
+			rows = []interface{}{document}
+		}
+		c.printTable(rows, columns)
+	}
+	return nil
+}
+
+func (c *cli) printTable(rows []interface{}, columns []string) {
+	w := tabwriter.NewWriter(c.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, strings.ToUpper(strings.Join(columns, "\t")))
+	for _, row := range rows {
+		fields, _ := row.(map[string]interface{})
+		values := make([]string, len(columns))
+		for i, column := range columns {
+			values[i] = formatCell(fields[column])
+		}
+		fmt.Fprintln(w, strings.Join(values, "\t"))
+	}
+	w.Flush()
+}
+
+// formatCell formats a field for the table output, references to other entities are shown as their id.
+func formatCell(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = formatCell(item)
+		}
+		return strings.Join(items, ",")
+	case map[string]interface{}:
+		if id, ok := v["id"]; ok && len(v) == 1 {
+			return formatCell(id)
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		items := make([]string, len(keys))
+		for i, key := range keys {
+			items[i] = key + "=" + formatCell(v[key])
+		}
+		return strings.Join(items, ",")
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
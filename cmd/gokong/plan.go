@@ -0,0 +1,366 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+
+	"gopkg.in/yaml.v2"
+)
+
+// exitDrift is the exit code of diff and apply -dry-run when kong does not match the state file.
+const exitDrift = 3
+
+var stateCommands = map[string]bool{"dump": true, "diff": true, "apply": true}
+
+// change is a change that makes an entity of kong match the state file.
+type change struct {
+	action  string
+	kind    *kind
+	desired map[string]interface{}
+	current *currentEntity
+	fields  []string
+}
+
+func (ch *change) String() string {
+	symbol := map[string]string{"create": "+", "update": "~", "delete": "-"}[ch.action]
+	line := fmt.Sprintf("%s %s %s", symbol, ch.kind.name, ch.kind.describe(ch.fieldsOrCurrent()))
+	if len(ch.fields) != 0 {
+		line += fmt.Sprintf(" (%v)", ch.fields)
+	}
+	return line
+}
+
+func (c *cli) runStateCommand(command string, args []string) int {
+	if len(args) != 0 {
+		fmt.Fprintf(c.stderr, "%s expects no arguments, got %d\n", command, len(args))
+		return 2
+	}
+	if c.file == "" {
+		fmt.Fprintf(c.stderr, "%s requires a state file given with -f\n", command)
+		return 2
+	}
+
+	current, err := c.loadSnapshot()
+	if err != nil {
+		return c.fail(err)
+	}
+
+	if command == "dump" {
+		return c.dump(current)
+	}
+
+	desired, err := c.readState()
+	if err != nil {
+		return c.fail(err)
+	}
+
+	changes, err := plan(current, desired)
+	if err != nil {
+		return c.fail(err)
+	}
+
+	if command == "diff" || c.dryRun {
+		for _, ch := range changes {
+			fmt.Fprintln(c.stdout, ch)
+		}
+		c.printSummary(changes, false)
+		if len(changes) != 0 {
+			return exitDrift
+		}
+		return 0
+	}
+
+	for _, ch := range changes {
+		if err := c.apply(current, ch); err != nil {
+			return c.fail(fmt.Errorf("could not %s %s %s, error: %v", ch.action, ch.kind.name, ch.kind.describe(ch.fieldsOrCurrent()), err))
+		}
+		fmt.Fprintln(c.stdout, ch)
+	}
+	c.printSummary(changes, true)
+	return 0
+}
+
+func (c *cli) dump(current *snapshot) int {
+	content, err := yaml.Marshal(current.state())
+	if err != nil {
+		return c.fail(fmt.Errorf("could not format state, error: %v", err))
+	}
+
+	if c.file == "-" {
+		_, err = c.stdout.Write(content)
+	} else {
+		err = ioutil.WriteFile(c.file, content, 0644)
+	}
+	if err != nil {
+		return c.fail(err)
+	}
+	return 0
+}
+
+// readState reads a state file into the documents of each kind, with the selected tags added to every entity.
+func (c *cli) readState() (map[string][]map[string]interface{}, error) {
+	data, err := c.readFile()
+	if err != nil {
+		return nil, err
+	}
+
+	var document interface{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("could not parse state file, error: %v", err)
+	}
+	normalised, err := normalise(toJsonValue(document))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse state file, error: %v", err)
+	}
+	sections, ok := normalised.(map[string]interface{})
+	if !ok && normalised != nil {
+		return nil, fmt.Errorf("could not parse state file, it must contain an object")
+	}
+
+	state := make(map[string][]map[string]interface{})
+	for name, section := range sections {
+		k := findKind(name)
+		if k == nil {
+			return nil, fmt.Errorf("unknown entity %s in state file", name)
+		}
+		items, _ := section.([]interface{})
+		for i, item := range items {
+			fields, ok := item.(map[string]interface{})
+			if !ok || fields[k.identity[0]] == nil {
+				return nil, fmt.Errorf("%s entry %d of the state file has no %s", name, i+1, k.identity[0])
+			}
+			c.addSelectedTags(fields)
+			state[name] = append(state[name], fields)
+		}
+	}
+	return state, nil
+}
+
+func (c *cli) addSelectedTags(fields map[string]interface{}) {
+	if len(c.selectTags) == 0 {
+		return
+	}
+	tags, _ := fields["tags"].([]interface{})
+	for _, tag := range c.selectTags {
+		if !hasTags(map[string]interface{}{"tags": tags}, []string{tag}) {
+			tags = append(tags, tag)
+		}
+	}
+	fields["tags"] = tags
+}
+
+// plan returns the changes that make the current state match the desired state: creations and updates in the order
+// of the kinds, then deletions in the reverse order.
+func plan(current *snapshot, desired map[string][]map[string]interface{}) ([]*change, error) {
+	changes := make([]*change, 0)
+	for _, k := range kinds {
+		seen := make(map[string]bool)
+		for _, fields := range desired[k.name] {
+			key := k.key(fields)
+			if seen[key] {
+				return nil, fmt.Errorf("%s %s is in the state file twice", k.name, k.describe(fields))
+			}
+			seen[key] = true
+
+			entity, exists := current.entities[k.name][key]
+			if !exists {
+				changes = append(changes, &change{action: "create", kind: k, desired: fields})
+				continue
+			}
+			if changed := changedFields(fields, entity.named); len(changed) != 0 {
+				changes = append(changes, &change{action: "update", kind: k, desired: fields, current: entity, fields: changed})
+			}
+		}
+	}
+
+	for i := len(kinds) - 1; i >= 0; i-- {
+		k := kinds[i]
+		desiredKeys := make(map[string]bool)
+		for _, fields := range desired[k.name] {
+			desiredKeys[k.key(fields)] = true
+		}
+		keys := make([]string, 0)
+		for key := range current.entities[k.name] {
+			if !desiredKeys[key] {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			changes = append(changes, &change{action: "delete", kind: k, current: current.entities[k.name][key]})
+		}
+	}
+
+	return changes, nil
+}
+
+// changedFields returns the fields of the state file that differ from kong. Fields left out of the state file are
+// not managed, and objects such as the config of a plugin only need to contain the fields that are set.
+func changedFields(desired map[string]interface{}, current map[string]interface{}) []string {
+	changed := make([]string, 0)
+	for field, value := range desired {
+		if !matches(value, current[field]) {
+			changed = append(changed, field)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+func matches(desired interface{}, current interface{}) bool {
+	desiredFields, ok := desired.(map[string]interface{})
+	if !ok {
+		return reflect.DeepEqual(desired, current)
+	}
+	currentFields, ok := current.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for field, value := range desiredFields {
+		if !matches(value, currentFields[field]) {
+			return false
+		}
+	}
+	return true
+}
+
+// apply makes a change through the entity clients of the cli. References by name are resolved to the ids of the
+// current entities, or of the entities created by earlier changes.
+func (c *cli) apply(current *snapshot, ch *change) error {
+	e := entities[ch.kind.name]
+
+	if ch.action == "delete" {
+		parent := ""
+		if ch.kind.name == "targets" {
+			parent = referenceId(ch.current.fields["upstream"])
+		}
+		return e.delete(c.client, parent, ch.current.id)
+	}
+
+	fields, err := current.resolve(ch.kind, ch.desired)
+	if err != nil {
+		return err
+	}
+	parent := ""
+	if ch.kind.name == "targets" {
+		parent = referenceId(fields["upstream"])
+	}
+
+	// targets cannot be updated, creating a target again replaces it
+	if ch.action == "create" || ch.kind.name == "targets" {
+		data, err := json.Marshal(fields)
+		if err != nil {
+			return err
+		}
+		result, err := e.create(c.client, parent, data)
+		if err != nil {
+			return err
+		}
+		created, err := normalise(result)
+		if err != nil {
+			return err
+		}
+		if createdFields, ok := created.(map[string]interface{}); ok && createdFields["id"] != nil {
+			name := fmt.Sprint(ch.desired[ch.kind.identity[0]])
+			current.ids[ch.kind.name][name] = fmt.Sprint(createdFields["id"])
+		}
+		return nil
+	}
+
+	merged := make(map[string]interface{})
+	for field, value := range ch.current.fields {
+		merged[field] = value
+	}
+	for field, value := range fields {
+		merged[field] = mergeValue(value, merged[field])
+	}
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return err
+	}
+	_, err = e.update(c.client, parent, ch.current.id, data)
+	return err
+}
+
+// resolve replaces the references by name of the fields with references by id.
+func (s *snapshot) resolve(k *kind, fields map[string]interface{}) (map[string]interface{}, error) {
+	resolved := make(map[string]interface{})
+	for field, value := range fields {
+		refKind, isRef := k.refs[field]
+		if !isRef || value == nil {
+			resolved[field] = value
+			continue
+		}
+		name := fmt.Sprint(value)
+		id, ok := s.ids[refKind][name]
+		if !ok {
+			// a reference to an entity without a name is its id
+			if _, isId := s.names[refKind][name]; !isId {
+				return nil, fmt.Errorf("%s %s does not exist", refKind, name)
+			}
+			id = name
+		}
+		resolved[field] = map[string]interface{}{"id": id}
+	}
+	return resolved, nil
+}
+
+// mergeValue sets the fields of an object of the state file over the current object, so that e.g. the config of a
+// plugin keeps the fields that are not in the state file.
+func mergeValue(desired interface{}, current interface{}) interface{} {
+	desiredFields, ok := desired.(map[string]interface{})
+	currentFields, isMap := current.(map[string]interface{})
+	if !ok || !isMap {
+		return desired
+	}
+	merged := make(map[string]interface{})
+	for field, value := range currentFields {
+		merged[field] = value
+	}
+	for field, value := range desiredFields {
+		merged[field] = mergeValue(value, merged[field])
+	}
+	return merged
+}
+
+func referenceId(value interface{}) string {
+	if reference, ok := value.(map[string]interface{}); ok {
+		return fmt.Sprint(reference["id"])
+	}
+	return ""
+}
+
+func (ch *change) fieldsOrCurrent() map[string]interface{} {
+	if ch.desired != nil {
+		return ch.desired
+	}
+	return ch.current.named
+}
+
+func (c *cli) printSummary(changes []*change, applied bool) {
+	if len(changes) == 0 {
+		fmt.Fprintln(c.stdout, "no changes")
+		return
+	}
+	counts := make(map[string]int)
+	for _, ch := range changes {
+		counts[ch.action]++
+	}
+	if applied {
+		fmt.Fprintf(c.stdout, "applied: %d created, %d updated, %d deleted\n", counts["create"], counts["update"], counts["delete"])
+		return
+	}
+	fmt.Fprintf(c.stdout, "plan: %d to create, %d to update, %d to delete\n", counts["create"], counts["update"], counts["delete"])
+}
+
+func findKind(name string) *kind {
+	for _, k := range kinds {
+		if k.name == name {
+			return k
+		}
+	}
+	return nil
+}
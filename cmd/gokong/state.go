@@ -0,0 +1,245 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/globocom/gokong"
+)
+
+// kind describes a type of entity managed by dump, diff and apply. Entities are identified by their identity fields
+// instead of their ids, so that a state file can be applied to another kong. References to other entities, such as
+// the service of a route, are written with the name of the referenced entity.
+type kind struct {
+	name     string
+	identity []string
+	refs     map[string]string
+}
+
+// kinds are ordered so that referenced entities are created before the entities that refer to them, and deleted
+// after them.
+var kinds = []*kind{
+	{name: "services", identity: []string{"name"}},
+	{name: "routes", identity: []string{"name"}, refs: map[string]string{"service": "services"}},
+	{name: "consumers", identity: []string{"username"}},
+	{name: "upstreams", identity: []string{"name"}},
+	{name: "targets", identity: []string{"upstream", "target"}, refs: map[string]string{"upstream": "upstreams"}},
+	{name: "plugins", identity: []string{"name", "service", "route", "consumer"}, refs: map[string]string{
+		"service":  "services",
+		"route":    "routes",
+		"consumer": "consumers",
+	}},
+}
+
+// generatedFields are set by kong and are not part of the state.
+var generatedFields = []string{"id", "created_at", "updated_at", "health"}
+
+// currentEntity is an entity read from kong, fields holds its json document and named the document written to a
+// state file, without the generated fields and with references by name.
+type currentEntity struct {
+	id     string
+	fields map[string]interface{}
+	named  map[string]interface{}
+}
+
+// snapshot is the state of kong, the entities are keyed by the values of their identity fields.
+type snapshot struct {
+	entities map[string]map[string]*currentEntity
+	// ids and names map the first identity field of the entities, e.g. the name of a service, to their id and back
+	ids   map[string]map[string]string
+	names map[string]map[string]string
+}
+
+func (k *kind) key(fields map[string]interface{}) string {
+	values := make([]string, 0, len(k.identity))
+	for _, field := range k.identity {
+		value := ""
+		if fields[field] != nil {
+			value = fmt.Sprint(fields[field])
+		}
+		values = append(values, value)
+	}
+	return strings.Join(values, "\x00")
+}
+
+// describe formats the identity of an entity for the output of diff and apply, e.g. rate-limiting service=example.
+func (k *kind) describe(fields map[string]interface{}) string {
+	parts := make([]string, 0, len(k.identity))
+	for i, field := range k.identity {
+		if fields[field] == nil {
+			continue
+		}
+		if i == 0 || k.refs[field] == "" {
+			parts = append(parts, fmt.Sprint(fields[field]))
+		} else {
+			parts = append(parts, fmt.Sprintf("%s=%v", field, fields[field]))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// loadSnapshot reads the entities of kong, keeping only the entities with the selected tags.
+func (c *cli) loadSnapshot() (*snapshot, error) {
+	s := &snapshot{
+		entities: make(map[string]map[string]*currentEntity),
+		ids:      make(map[string]map[string]string),
+		names:    make(map[string]map[string]string),
+	}
+
+	documents := make(map[string][]map[string]interface{})
+	for _, k := range kinds {
+		var err error
+		if k.name == "targets" {
+			documents[k.name], err = c.listTargets(documents["upstreams"])
+		} else {
+			documents[k.name], err = c.listDocuments(k.name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not list %s, error: %v", k.name, err)
+		}
+
+		s.ids[k.name] = make(map[string]string)
+		s.names[k.name] = make(map[string]string)
+		for _, document := range documents[k.name] {
+			if id, ok := document["id"].(string); ok && document[k.identity[0]] != nil {
+				s.ids[k.name][fmt.Sprint(document[k.identity[0]])] = id
+				s.names[k.name][id] = fmt.Sprint(document[k.identity[0]])
+			}
+		}
+
+		s.entities[k.name] = make(map[string]*currentEntity)
+		for _, document := range documents[k.name] {
+			if !hasTags(document, c.selectTags) {
+				continue
+			}
+			if document[k.identity[0]] == nil {
+				fmt.Fprintf(c.stderr, "skipping %s %v, it has no %s\n", k.name, document["id"], k.identity[0])
+				continue
+			}
+			entity := &currentEntity{fields: document, named: make(map[string]interface{})}
+			entity.id, _ = document["id"].(string)
+			for field, value := range document {
+				if value == nil || contains(generatedFields, field) {
+					continue
+				}
+				if refKind, ok := k.refs[field]; ok {
+					value = s.referenceName(refKind, value)
+				}
+				entity.named[field] = value
+			}
+			s.entities[k.name][k.key(entity.named)] = entity
+		}
+	}
+
+	return s, nil
+}
+
+func (c *cli) listDocuments(name string) ([]map[string]interface{}, error) {
+	result, err := entities[name].list(c.client, "")
+	if err != nil {
+		return nil, err
+	}
+	return toDocuments(result)
+}
+
+// listTargets lists the targets of every upstream, targets with a weight of 0 have been removed from their upstream.
+func (c *cli) listTargets(upstreams []map[string]interface{}) ([]map[string]interface{}, error) {
+	targets := make([]map[string]interface{}, 0)
+	for _, upstream := range upstreams {
+		id := fmt.Sprint(upstream["id"])
+		result, err := c.client.Targets().ListFromUpstream(id, &gokong.TargetQueryString{})
+		if err != nil {
+			return nil, err
+		}
+		documents, err := toDocuments(result)
+		if err != nil {
+			return nil, err
+		}
+		for _, target := range documents {
+			if weight, ok := target["weight"].(float64); ok && weight == 0 {
+				continue
+			}
+			target["upstream"] = map[string]interface{}{"id": id}
+			targets = append(targets, target)
+		}
+	}
+	return targets, nil
+}
+
+// referenceName replaces a reference by id with the name of the referenced entity, references to entities without
+// a name are kept as ids.
+func (s *snapshot) referenceName(refKind string, value interface{}) interface{} {
+	reference, ok := value.(map[string]interface{})
+	if !ok {
+		return value
+	}
+	id := fmt.Sprint(reference["id"])
+	if name, ok := s.names[refKind][id]; ok {
+		return name
+	}
+	return id
+}
+
+func toDocuments(value interface{}) ([]map[string]interface{}, error) {
+	document, err := normalise(value)
+	if err != nil {
+		return nil, err
+	}
+	items, _ := document.([]interface{})
+	documents := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		if fields, ok := item.(map[string]interface{}); ok {
+			documents = append(documents, fields)
+		}
+	}
+	return documents, nil
+}
+
+// state returns the named documents of the snapshot, as written to a state file.
+func (s *snapshot) state() map[string]interface{} {
+	state := make(map[string]interface{})
+	for _, k := range kinds {
+		keys := make([]string, 0, len(s.entities[k.name]))
+		for key := range s.entities[k.name] {
+			keys = append(keys, key)
+		}
+		if len(keys) == 0 {
+			continue
+		}
+		sort.Strings(keys)
+
+		documents := make([]interface{}, 0, len(keys))
+		for _, key := range keys {
+			documents = append(documents, s.entities[k.name][key].named)
+		}
+		state[k.name] = documents
+	}
+	return state
+}
+
+func hasTags(document map[string]interface{}, tags []string) bool {
+	entityTags, _ := document["tags"].([]interface{})
+	for _, tag := range tags {
+		found := false
+		for _, entityTag := range entityTags {
+			if entityTag == tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/globocom/gokong"
+	"github.com/globocom/gokong/gokongtest"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestDumpDiffApply(t *testing.T) {
+	server := gokongtest.NewServer()
+	defer server.Close()
+	client := gokong.NewClient(&gokong.Config{HostAddress: server.URL})
+
+	service, err := client.Services().Create(&gokong.ServiceRequest{
+		Name:     gokong.String("service"),
+		Protocol: gokong.String("http"),
+		Host:     gokong.String("example.com"),
+		Tags:     gokong.StringSlice([]string{"team-a"}),
+	})
+	assert.Nil(t, err)
+	_, err = client.Routes().Create(&gokong.RouteRequest{
+		Name:    gokong.String("route"),
+		Paths:   gokong.StringSlice([]string{"/route"}),
+		Service: gokong.ToId(*service.Id),
+		Tags:    gokong.StringSlice([]string{"team-a"}),
+	})
+	assert.Nil(t, err)
+	_, err = client.Consumers().Create(&gokong.ConsumerRequest{Username: "untagged"})
+	assert.Nil(t, err)
+
+	dir, err := ioutil.TempDir("", "gokong")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "kong.yaml")
+
+	code, _, _ := runCommand(server, "", "dump", "-f", file, "-select-tag", "team-a")
+	assert.Equal(t, 0, code)
+	content, err := ioutil.ReadFile(file)
+	assert.Nil(t, err)
+	state := map[string][]map[string]interface{}{}
+	assert.Nil(t, yaml.Unmarshal(content, &state))
+	assert.Len(t, state["services"], 1)
+	assert.Len(t, state["routes"], 1)
+	assert.Equal(t, "service", state["routes"][0]["service"])
+	assert.NotContains(t, state["routes"][0], "id")
+	assert.NotContains(t, state, "consumers")
+
+	code, stdout, _ := runCommand(server, "", "diff", "-f", file, "-select-tag", "team-a")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "no changes\n", stdout)
+
+	desired := `
+services:
+- name: service
+  protocol: http
+  host: example.org
+- name: other
+  protocol: http
+  host: other.example.com
+routes:
+- name: other-route
+  paths: [/other]
+  service: other
+`
+	assert.Nil(t, ioutil.WriteFile(file, []byte(desired), 0644))
+
+	code, stdout, _ = runCommand(server, "", "diff", "-f", file, "-select-tag", "team-a")
+	assert.Equal(t, exitDrift, code)
+	assert.Equal(t, "~ services service ([host])\n"+
+		"+ services other\n"+
+		"+ routes other-route\n"+
+		"- routes route\n"+
+		"plan: 2 to create, 1 to update, 1 to delete\n", stdout)
+
+	code, _, _ = runCommand(server, "", "apply", "-f", file, "-select-tag", "team-a", "-dry-run")
+	assert.Equal(t, exitDrift, code)
+	route, err := client.Routes().GetByName("route")
+	assert.Nil(t, err)
+	assert.NotNil(t, route)
+
+	code, stdout, stderr := runCommand(server, "", "apply", "-f", file, "-select-tag", "team-a")
+	assert.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "applied: 2 created, 1 updated, 1 deleted\n")
+
+	code, stdout, _ = runCommand(server, "", "diff", "-f", file, "-select-tag", "team-a")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "no changes\n", stdout)
+
+	updated, err := client.Services().GetServiceByName("service")
+	assert.Nil(t, err)
+	assert.Equal(t, "example.org", *updated.Host)
+	otherRoute, err := client.Routes().GetByName("other-route")
+	assert.Nil(t, err)
+	other, err := client.Services().GetServiceByName("other")
+	assert.Nil(t, err)
+	assert.Equal(t, gokong.Id(*other.Id), *otherRoute.Service)
+	assert.Equal(t, []*string{gokong.String("team-a")}, other.Tags)
+	route, err = client.Routes().GetByName("route")
+	assert.Nil(t, err)
+	assert.Nil(t, route)
+	untagged, err := client.Consumers().GetByUsername("untagged")
+	assert.Nil(t, err)
+	assert.NotNil(t, untagged)
+}
+
+func TestApplyPluginsAndTargets(t *testing.T) {
+	server := gokongtest.NewServer()
+	defer server.Close()
+	client := gokong.NewClient(&gokong.Config{HostAddress: server.URL})
+
+	desired := `
+services:
+- name: service
+  protocol: http
+  host: example.com
+plugins:
+- name: rate-limiting
+  service: service
+  config:
+    minute: 10
+upstreams:
+- name: upstream
+targets:
+- upstream: upstream
+  target: example.com:80
+  weight: 100
+`
+	code, stdout, stderr := runCommand(server, desired, "apply", "-f", "-")
+	assert.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "+ plugins rate-limiting service=service\n")
+	assert.Contains(t, stdout, "+ targets upstream example.com:80\n")
+
+	code, stdout, _ = runCommand(server, desired, "diff", "-f", "-")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "no changes\n", stdout)
+
+	plugins, err := client.Plugins().List(&gokong.PluginQueryString{})
+	assert.Nil(t, err)
+	assert.Len(t, plugins, 1)
+	targets, err := client.Targets().GetTargetsFromUpstreamName("upstream")
+	assert.Nil(t, err)
+	assert.Len(t, targets, 1)
+}
+
+func TestStateCommandErrors(t *testing.T) {
+	server := gokongtest.NewServer()
+	defer server.Close()
+
+	code, _, stderr := runCommand(server, "", "diff")
+	assert.Equal(t, 2, code)
+	assert.Equal(t, "diff requires a state file given with -f\n", stderr)
+
+	code, _, stderr = runCommand(server, "apis: []\n", "diff", "-f", "-")
+	assert.Equal(t, 1, code)
+	assert.Equal(t, "error: unknown entity apis in state file\n", stderr)
+
+	code, _, stderr = runCommand(server, "routes:\n- name: route\n  service: missing\n", "apply", "-f", "-")
+	assert.Equal(t, 1, code)
+	assert.Equal(t, "error: could not create routes route, error: services missing does not exist\n", stderr)
+}
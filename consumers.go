@@ -18,6 +18,9 @@ type ConsumerClient interface {
 	GetPluginConfig(consumerId string, pluginName string, id string) (*ConsumerPluginConfig, error)
 	GetPluginConfigs(consumerId string, pluginName string) ([]map[string]interface{}, error)
 	DeletePluginConfig(consumerId string, pluginName string, id string) error
+	CreateMany(consumerRequests []*ConsumerRequest, options *BulkOptions) ([]*Consumer, error)
+	UpdateMany(ids []string, consumerRequests []*ConsumerRequest, options *BulkOptions) ([]*Consumer, error)
+	DeleteMany(ids []string, options *BulkOptions) error
 }
 
 type consumerClient struct {
@@ -278,3 +281,36 @@ func (consumerClient *consumerClient) DeletePluginConfig(consumerId string, plug
 
 	return nil
 }
+
+// CreateMany creates the consumers with a bounded number of requests at once. The created consumers are returned in
+// the order of the requests, with nil for the consumers that could not be created, and the error is a *BulkError.
+func (consumerClient *consumerClient) CreateMany(consumerRequests []*ConsumerRequest, options *BulkOptions) ([]*Consumer, error) {
+	consumers := make([]*Consumer, len(consumerRequests))
+	err := runBulk(consumerClient.config, len(consumerRequests), options, func(i int) error {
+		var err error
+		consumers[i], err = consumerClient.Create(consumerRequests[i])
+		return err
+	})
+	return consumers, err
+}
+
+// UpdateMany updates the consumer of each id with the request at the same index, in the same way as CreateMany.
+func (consumerClient *consumerClient) UpdateMany(ids []string, consumerRequests []*ConsumerRequest, options *BulkOptions) ([]*Consumer, error) {
+	if err := checkBulkUpdate(len(ids), len(consumerRequests)); err != nil {
+		return nil, err
+	}
+	consumers := make([]*Consumer, len(ids))
+	err := runBulk(consumerClient.config, len(ids), options, func(i int) error {
+		var err error
+		consumers[i], err = consumerClient.UpdateById(ids[i], consumerRequests[i])
+		return err
+	})
+	return consumers, err
+}
+
+// DeleteMany deletes the consumers with a bounded number of requests at once, the error is a *BulkError.
+func (consumerClient *consumerClient) DeleteMany(ids []string, options *BulkOptions) error {
+	return runBulk(consumerClient.config, len(ids), options, func(i int) error {
+		return consumerClient.DeleteById(ids[i])
+	})
+}
@@ -0,0 +1,93 @@
+package containers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"time"
+
+	"github.com/ory/dockertest"
+)
+
+type cassandraContainer struct {
+	Name     string
+	Address  string
+	pool     *dockertest.Pool
+	resource *dockertest.Resource
+}
+
+func NewCassandraContainer(pool *dockertest.Pool) *cassandraContainer {
+	resource, err := pool.Run("cassandra", "3.11", []string{
+		"MAX_HEAP_SIZE=256M",
+		"HEAP_NEWSIZE=128M",
+	})
+
+	if err != nil {
+		log.Fatalf("Could not start resource: %s", err)
+	}
+
+	address := fmt.Sprintf("localhost:%s", resource.GetPort("9042/tcp"))
+	containerName := getContainerName(resource)
+
+	if err = pool.Retry(func() error {
+		return pingCassandra(address)
+	}); err != nil {
+		log.Fatalf("Could not connect to docker: %s", err)
+	}
+
+	log.Printf("Cassandra (%v): up", containerName)
+
+	return &cassandraContainer{
+		Name:     containerName,
+		Address:  address,
+		pool:     pool,
+		resource: resource,
+	}
+}
+
+func (cassandra *cassandraContainer) Stop() error {
+	return cassandra.pool.Purge(cassandra.resource)
+}
+
+func (cassandra *cassandraContainer) kongEnv() []string {
+	return []string{
+		"KONG_DATABASE=cassandra",
+		fmt.Sprintf("KONG_CASSANDRA_CONTACT_POINTS=%s", cassandra.Name),
+	}
+}
+
+func (cassandra *cassandraContainer) link() string {
+	return fmt.Sprintf("%s:cassandra", cassandra.Name)
+}
+
+// pingCassandra sends an OPTIONS request of the cql native protocol and waits for the SUPPORTED response, docker
+// accepts connections on the published port before cassandra is ready so a successful connection is not enough.
+func pingCassandra(address string) error {
+	conn, err := net.DialTimeout("tcp", address, 5*time.Second)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		return err
+	}
+
+	// version 4 request, no flags, stream 0, opcode OPTIONS and an empty body
+	if _, err := conn.Write([]byte{0x04, 0x00, 0x00, 0x00, 0x05, 0x00, 0x00, 0x00, 0x00}); err != nil {
+		return err
+	}
+
+	header := make([]byte, 9)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return err
+	}
+
+	if header[4] != 0x06 {
+		return errors.New(fmt.Sprintf("Cassandra not ready, unexpected opcode %d", header[4]))
+	}
+
+	return nil
+}
@@ -0,0 +1,261 @@
+package containers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// FixtureEntity is an entity created in kong by a FixtureBuilder.
+type FixtureEntity struct {
+	Type string
+	Id   string
+	Name string
+}
+
+// FixtureBuilder creates services, routes, consumers and plugins described in a yaml fixture through the admin api and
+// keeps track of them so they can be deleted once the test is done, e.g.:
+//
+//	services:
+//	  - name: service
+//	    url: http://example.com
+//	    routes:
+//	      - name: route
+//	        paths: [/]
+//	    plugins:
+//	      - name: key-auth
+//	consumers:
+//	  - username: user
+//	plugins:
+//	  - name: rate-limiting
+//	    consumer: user
+//	    config:
+//	      minute: 10
+//
+// Top level plugins refer to services, routes and consumers by name or id.
+type FixtureBuilder struct {
+	hostAddress string
+	client      *http.Client
+	created     []*FixtureEntity
+}
+
+type fixture struct {
+	Services  []map[string]interface{} `yaml:"services"`
+	Consumers []map[string]interface{} `yaml:"consumers"`
+	Plugins   []map[string]interface{} `yaml:"plugins"`
+}
+
+// NewFixtureBuilder creates a fixture builder for the kong admin api listening on hostAddress.
+func NewFixtureBuilder(hostAddress string) *FixtureBuilder {
+	return &FixtureBuilder{
+		hostAddress: strings.TrimRight(hostAddress, "/"),
+		client:      &http.Client{},
+	}
+}
+
+// LoadFile creates the entities described in the yaml fixture file.
+func (builder *FixtureBuilder) LoadFile(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("could not read fixture %s, error: %v", path, err)
+	}
+	return builder.Load(data)
+}
+
+// Load creates the entities described in a yaml fixture. Services are created first with their routes and plugins,
+// then consumers with their plugins and finally the top level plugins.
+func (builder *FixtureBuilder) Load(data []byte) error {
+	f := &fixture{}
+	if err := yaml.Unmarshal(data, f); err != nil {
+		return fmt.Errorf("could not parse fixture, error: %v", err)
+	}
+
+	for _, service := range f.Services {
+		created, err := builder.create("services", "/services", service, "routes", "plugins")
+		if err != nil {
+			return err
+		}
+
+		for _, route := range toEntities(service["routes"]) {
+			createdRoute, err := builder.create("routes", fmt.Sprintf("/services/%s/routes", created.Id), route, "plugins")
+			if err != nil {
+				return err
+			}
+			for _, plugin := range toEntities(route["plugins"]) {
+				if _, err := builder.create("plugins", fmt.Sprintf("/routes/%s/plugins", createdRoute.Id), plugin); err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, plugin := range toEntities(service["plugins"]) {
+			if _, err := builder.create("plugins", fmt.Sprintf("/services/%s/plugins", created.Id), plugin); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, consumer := range f.Consumers {
+		created, err := builder.create("consumers", "/consumers", consumer, "plugins")
+		if err != nil {
+			return err
+		}
+
+		for _, plugin := range toEntities(consumer["plugins"]) {
+			if _, err := builder.create("plugins", fmt.Sprintf("/consumers/%s/plugins", created.Id), plugin); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, plugin := range f.Plugins {
+		for _, field := range []string{"service", "route", "consumer"} {
+			if reference, ok := plugin[field].(string); ok {
+				plugin[field] = map[string]interface{}{"id": builder.resolve(field+"s", reference)}
+			}
+		}
+		if _, err := builder.create("plugins", "/plugins", plugin); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Created returns the entities created by the builder in creation order.
+func (builder *FixtureBuilder) Created() []*FixtureEntity {
+	return builder.created
+}
+
+// Id returns the id of an entity created by the builder from its type, e.g. services, and its name or username.
+func (builder *FixtureBuilder) Id(entityType string, name string) string {
+	for _, entity := range builder.created {
+		if entity.Type == entityType && entity.Name == name {
+			return entity.Id
+		}
+	}
+	return ""
+}
+
+// Teardown deletes the entities created by the builder in reverse creation order.
+func (builder *FixtureBuilder) Teardown() error {
+	for i := len(builder.created) - 1; i >= 0; i-- {
+		entity := builder.created[i]
+		if err := deleteEntity(builder.client, builder.hostAddress, entity.Type, entity.Id); err != nil {
+			return err
+		}
+	}
+	builder.created = nil
+	return nil
+}
+
+func (builder *FixtureBuilder) resolve(entityType string, reference string) string {
+	if id := builder.Id(entityType, reference); id != "" {
+		return id
+	}
+	return reference
+}
+
+func (builder *FixtureBuilder) create(entityType string, path string, entity map[string]interface{}, children ...string) (*FixtureEntity, error) {
+	body := toJsonValue(entity).(map[string]interface{})
+	for _, child := range children {
+		delete(body, child)
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("could not create %s from fixture, error: %v", entityType, err)
+	}
+
+	resp, err := builder.client.Post(builder.hostAddress+path, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("could not create %s from fixture, error: %v", entityType, err)
+	}
+	defer resp.Body.Close()
+
+	responseBody, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("could not create %s from fixture, message from kong: %s", entityType, responseBody)
+	}
+
+	result := map[string]interface{}{}
+	if err := json.Unmarshal(responseBody, &result); err != nil {
+		return nil, fmt.Errorf("could not parse %s creation response, error: %v", entityType, err)
+	}
+
+	created := &FixtureEntity{Type: entityType}
+	created.Id, _ = result["id"].(string)
+	if name, ok := result["name"].(string); ok {
+		created.Name = name
+	} else if username, ok := result["username"].(string); ok {
+		created.Name = username
+	}
+
+	builder.created = append(builder.created, created)
+	return created, nil
+}
+
+func deleteEntity(client *http.Client, hostAddress string, entityType string, id string) error {
+	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/%s/%s", hostAddress, entityType, id), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not delete %s %s, error: %v", entityType, id, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("could not delete %s %s, message from kong: %s", entityType, id, body)
+	}
+
+	return nil
+}
+
+// toEntities converts the nested entities of a fixture, which the yaml parser decodes with interface{} keys.
+func toEntities(value interface{}) []map[string]interface{} {
+	list, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	result := make([]map[string]interface{}, 0, len(list))
+	for _, item := range list {
+		if entity, ok := toJsonValue(item).(map[string]interface{}); ok {
+			result = append(result, entity)
+		}
+	}
+	return result
+}
+
+func toJsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			result[fmt.Sprint(key)] = toJsonValue(item)
+		}
+		return result
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			result[key] = toJsonValue(item)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = toJsonValue(item)
+		}
+		return result
+	}
+	return value
+}
@@ -0,0 +1,94 @@
+package containers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/globocom/gokong/containers"
+	"github.com/globocom/gokong/gokongtest"
+	"github.com/stretchr/testify/assert"
+)
+
+const fixture = `
+services:
+  - name: service
+    url: http://example.com
+    routes:
+      - name: route
+        paths: [/]
+        plugins:
+          - name: cors
+    plugins:
+      - name: key-auth
+consumers:
+  - username: user
+    plugins:
+      - name: acl
+plugins:
+  - name: rate-limiting
+    consumer: user
+    service: service
+    config:
+      minute: 10
+`
+
+func count(t *testing.T, address string, collection string) int {
+	resp, err := http.Get(address + "/" + collection)
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+
+	page := &struct {
+		Data []interface{} `json:"data"`
+	}{}
+	assert.Nil(t, json.NewDecoder(resp.Body).Decode(page))
+	return len(page.Data)
+}
+
+func TestFixtureBuilder_LoadAndTeardown(t *testing.T) {
+	server := gokongtest.NewServer()
+	defer server.Close()
+	builder := containers.NewFixtureBuilder(server.URL)
+
+	err := builder.Load([]byte(fixture))
+
+	assert.Nil(t, err)
+	assert.Len(t, builder.Created(), 7)
+	assert.NotEmpty(t, builder.Id("services", "service"))
+	assert.NotEmpty(t, builder.Id("consumers", "user"))
+	assert.Equal(t, 1, count(t, server.URL, "services"))
+	assert.Equal(t, 1, count(t, server.URL, "routes"))
+	assert.Equal(t, 4, count(t, server.URL, "plugins"))
+
+	err = builder.Teardown()
+
+	assert.Nil(t, err)
+	assert.Len(t, builder.Created(), 0)
+	assert.Equal(t, 0, count(t, server.URL, "services"))
+	assert.Equal(t, 0, count(t, server.URL, "consumers"))
+	assert.Equal(t, 0, count(t, server.URL, "plugins"))
+}
+
+func TestFixtureBuilder_LoadFailsOnInvalidEntity(t *testing.T) {
+	server := gokongtest.NewServer()
+	defer server.Close()
+	builder := containers.NewFixtureBuilder(server.URL)
+
+	err := builder.Load([]byte("services:\n  - name: service\n"))
+
+	assert.NotNil(t, err)
+	assert.Len(t, builder.Created(), 0)
+}
+
+func TestResetKong(t *testing.T) {
+	server := gokongtest.NewServer()
+	defer server.Close()
+	assert.Nil(t, containers.NewFixtureBuilder(server.URL).Load([]byte(fixture)))
+
+	err := containers.ResetKong(server.URL)
+
+	assert.Nil(t, err)
+	for _, collection := range []string{"services", "routes", "consumers", "plugins"} {
+		assert.Equal(t, 0, count(t, server.URL, collection))
+	}
+}
@@ -5,11 +5,14 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"path/filepath"
 
 	"github.com/ory/dockertest"
 	uuid "github.com/satori/go.uuid"
 )
 
+const declarativeConfigMountPath = "/kong/declarative/kong.yml"
+
 type kongContainer struct {
 	Name        string
 	pool        *dockertest.Pool
@@ -17,44 +20,107 @@ type kongContainer struct {
 	HostAddress string
 }
 
+// kongDatabase is a database container kong can store its entities in.
+type kongDatabase interface {
+	container
+	kongEnv() []string
+	link() string
+}
+
 func NewKongContainerDockerfile(pool *dockertest.Pool, postgres *postgresContainer, dockerfilePath string) *kongContainer {
+	return newKongContainer(pool, postgresDatabase(postgres), "", dockerfilePath, "")
+}
+
+func NewKongContainer(pool *dockertest.Pool, postgres *postgresContainer, kongVersion string) *kongContainer {
+	return newKongContainer(pool, postgresDatabase(postgres), kongVersion, "", "")
+}
+
+// NewDBLessKongContainer starts kong without a database, loading its entities from the declarative config file when
+// declarativeConfigPath is set.
+func NewDBLessKongContainer(pool *dockertest.Pool, kongVersion, declarativeConfigPath string) *kongContainer {
+	return newKongContainer(pool, nil, kongVersion, "", declarativeConfigPath)
+}
+
+// NewCassandraKongContainer starts kong storing its entities in cassandra.
+func NewCassandraKongContainer(pool *dockertest.Pool, cassandra *cassandraContainer, kongVersion string) *kongContainer {
+	var database kongDatabase
+	if cassandra != nil {
+		database = cassandra
+	}
+	return newKongContainer(pool, database, kongVersion, "", "")
+}
+
+// postgresDatabase returns postgres as a kongDatabase, a nil container must become a nil interface so that kong is
+// started without a database instead of with a nil one.
+func postgresDatabase(postgres *postgresContainer) kongDatabase {
+	if postgres == nil {
+		return nil
+	}
+	return postgres
+}
+
+func newKongContainer(pool *dockertest.Pool, database kongDatabase, kongVersion, dockerfilePath, declarativeConfigPath string) *kongContainer {
 	envVars := []string{
-		"KONG_DATABASE=postgres",
 		"KONG_ADMIN_LISTEN=0.0.0.0:8001",
-		fmt.Sprintf("KONG_PG_HOST=%s", postgres.Name),
-		fmt.Sprintf("KONG_PG_USER=%s", postgres.DatabaseUser),
-		fmt.Sprintf("KONG_PG_PASSWORD=%s", postgres.Password),
 		"KONG_PROXY_ACCESS_LOG=/dev/stdout",
 		"KONG_PROXY_ERROR_LOG=/dev/stderr",
 		"KONG_ADMIN_ACCESS_LOG=/dev/stdout",
 		"KONG_ADMIN_ERROR_LOG=/dev/stderr",
 	}
 
-	runOptions := &dockertest.RunOptions{
-		Name:  fmt.Sprintf("gokong-kong-tests-%s", uuid.NewV4().String()),
-		Env:   envVars,
-		Links: []string{fmt.Sprintf("%s:postgres", postgres.Name)},
+	var links []string
+	var mounts []string
+	if database != nil {
+		envVars = append(envVars, database.kongEnv()...)
+		links = []string{database.link()}
+	} else {
+		envVars = append(envVars, "KONG_DATABASE=off")
+		if declarativeConfigPath != "" {
+			path, err := filepath.Abs(declarativeConfigPath)
+			if err != nil {
+				log.Fatalf("Could not find declarative config %s: %s", declarativeConfigPath, err)
+			}
+			envVars = append(envVars, fmt.Sprintf("KONG_DECLARATIVE_CONFIG=%s", declarativeConfigMountPath))
+			mounts = []string{fmt.Sprintf("%s:%s", path, declarativeConfigMountPath)}
+		}
 	}
 
-	resource, err := pool.BuildAndRunWithOptions(dockerfilePath, runOptions)
+	var resource *dockertest.Resource
+	var err error
+	if dockerfilePath != "" {
+		resource, err = pool.BuildAndRunWithOptions(dockerfilePath, &dockertest.RunOptions{
+			Name:   fmt.Sprintf("gokong-kong-tests-%s", uuid.NewV4().String()),
+			Env:    envVars,
+			Links:  links,
+			Mounts: mounts,
+		})
+	} else {
+		if database != nil {
+			runMigrations(pool, kongVersion, envVars, links)
+		}
+		resource, err = pool.RunWithOptions(&dockertest.RunOptions{
+			Repository: "kong",
+			Tag:        kongVersion,
+			Env:        envVars,
+			Links:      links,
+			Mounts:     mounts,
+		})
+	}
 	if err != nil {
-		log.Fatalf("Could not start resource: %s", err)
+		log.Fatalf("Could not start kong: %s", err)
 	}
 
 	kongContainerName := getContainerName(resource)
 	kongAddress := fmt.Sprintf("http://localhost:%v", resource.GetPort("8001/tcp"))
 
 	if err := pool.Retry(func() error {
-		var err error
 		statusEndpoint := fmt.Sprintf("%s/status", kongAddress)
-		if err != nil {
-			return err
-		}
 
 		resp, err := http.Get(statusEndpoint)
 		if err != nil {
 			return err
 		}
+		resp.Body.Close()
 
 		if resp.StatusCode >= 400 {
 			return errors.New(fmt.Sprintf("Kong not ready: %+v", resp))
@@ -75,25 +141,18 @@ func NewKongContainerDockerfile(pool *dockertest.Pool, postgres *postgresContain
 	}
 }
 
-func NewKongContainer(pool *dockertest.Pool, postgres *postgresContainer, kongVersion string) *kongContainer {
-	envVars := []string{
-		"KONG_DATABASE=postgres",
-		"KONG_ADMIN_LISTEN=0.0.0.0:8001",
-		fmt.Sprintf("KONG_PG_HOST=%s", postgres.Name),
-		fmt.Sprintf("KONG_PG_USER=%s", postgres.DatabaseUser),
-		fmt.Sprintf("KONG_PG_PASSWORD=%s", postgres.Password),
-	}
-
-	options := &dockertest.RunOptions{
+func runMigrations(pool *dockertest.Pool, kongVersion string, envVars, links []string) {
+	migrations, err := pool.RunWithOptions(&dockertest.RunOptions{
 		Repository: "kong",
 		Tag:        kongVersion,
 		Env:        envVars,
-		Links:      []string{postgres.Name},
+		Links:      links,
 		Cmd:        []string{"kong", "migrations", "bootstrap"},
+	})
+	if err != nil {
+		log.Fatalf("Could not start kong migrations: %s", err)
 	}
 
-	migrations, err := pool.RunWithOptions(options)
-
 	if err := pool.Retry(func() error {
 		migrationsContainer, err := pool.Client.InspectContainer(migrations.Container.ID)
 		migrationsContainerName := getContainerName(migrations)
@@ -109,53 +168,6 @@ func NewKongContainer(pool *dockertest.Pool, postgres *postgresContainer, kongVe
 	}); err != nil {
 		log.Fatalf("Could not connect to kong: %s", err)
 	}
-
-	if err != nil {
-		log.Fatalf("Could not start kong: %s", err)
-	}
-
-	options = &dockertest.RunOptions{
-		Repository: "kong",
-		Tag:        kongVersion,
-		Env:        envVars,
-		Links:      []string{fmt.Sprintf("%s:postgres", postgres.Name)},
-	}
-
-	resource, err := pool.RunWithOptions(options)
-
-	kongContainerName := getContainerName(resource)
-
-	kongAddress := fmt.Sprintf("http://localhost:%v", resource.GetPort("8001/tcp"))
-
-	if err := pool.Retry(func() error {
-		var err error
-		curlEndpoint := fmt.Sprintf("%s/status", kongAddress)
-		if err != nil {
-			return err
-		}
-
-		resp, err := http.Get(curlEndpoint)
-		if err != nil {
-			return err
-		}
-
-		if resp.StatusCode >= 400 {
-			return errors.New(fmt.Sprintf("Kong not ready: %+v", resp))
-		}
-
-		log.Printf("Kong (%v): up", kongContainerName)
-
-		return nil
-	}); err != nil {
-		log.Fatalf("Could not connect to kong: %s", err)
-	}
-
-	return &kongContainer{
-		Name:        kongContainerName,
-		pool:        pool,
-		resource:    resource,
-		HostAddress: kongAddress,
-	}
 }
 
 func (kong *kongContainer) Stop() error {
@@ -0,0 +1,12 @@
+package containers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostgresDatabase_NilContainerIsNoDatabase(t *testing.T) {
+	assert.True(t, postgresDatabase(nil) == nil)
+	assert.True(t, postgresDatabase(&postgresContainer{}) != nil)
+}
@@ -0,0 +1,75 @@
+package containers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"testing"
+)
+
+// TestRunner runs a test suite and returns its exit code, *testing.M is a TestRunner.
+type TestRunner interface {
+	Run() int
+}
+
+// RunMatrix runs the test suite against each kong version in turn. For each version it starts kong with the options,
+// calls setup with the test context so the tests can find kong, runs the tests and stops kong before moving to the
+// next version. It returns the first non zero exit code, after running every version.
+func RunMatrix(runner TestRunner, versions []string, options StartOptions, setup func(testContext *TestContext)) int {
+	code := 0
+	for _, version := range versions {
+		options.KongVersion = version
+		testContext := StartKongWithOptions(options)
+
+		log.Printf("Running tests against kong %s", testContext.KongVersion)
+		if setup != nil {
+			setup(testContext)
+		}
+
+		result := runner.Run()
+		StopKong(testContext)
+
+		if result != 0 {
+			log.Printf("Tests failed against kong %s", testContext.KongVersion)
+			if code == 0 {
+				code = result
+			}
+		}
+	}
+	return code
+}
+
+// VersionSatisfies reports whether the running kong satisfies a version constraint, see VersionSatisfies.
+func (testContext *TestContext) VersionSatisfies(constraint string) bool {
+	ok, err := VersionSatisfies(testContext.KongVersion, constraint)
+	if err != nil {
+		log.Printf("Could not check kong version: %v", err)
+		return false
+	}
+	return ok
+}
+
+// SkipUnlessVersion skips the test when the running kong does not satisfy a version constraint, e.g. ">= 1.3".
+func (testContext *TestContext) SkipUnlessVersion(t testing.TB, constraint string) {
+	t.Helper()
+	if !testContext.VersionSatisfies(constraint) {
+		t.Skipf("kong %s does not satisfy %s", testContext.KongVersion, constraint)
+	}
+}
+
+// kongVersion asks the kong admin api listening on hostAddress for its version.
+func kongVersion(hostAddress string) (string, error) {
+	resp, err := http.Get(hostAddress)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	info := &struct {
+		Version string `json:"version"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(info); err != nil {
+		return "", err
+	}
+	return info.Version, nil
+}
@@ -69,3 +69,16 @@ func NewPostgresContainer(pool *dockertest.Pool) *postgresContainer {
 func (postgres *postgresContainer) Stop() error {
 	return postgres.pool.Purge(postgres.resource)
 }
+
+func (postgres *postgresContainer) kongEnv() []string {
+	return []string{
+		"KONG_DATABASE=postgres",
+		fmt.Sprintf("KONG_PG_HOST=%s", postgres.Name),
+		fmt.Sprintf("KONG_PG_USER=%s", postgres.DatabaseUser),
+		fmt.Sprintf("KONG_PG_PASSWORD=%s", postgres.Password),
+	}
+}
+
+func (postgres *postgresContainer) link() string {
+	return fmt.Sprintf("%s:postgres", postgres.Name)
+}
@@ -0,0 +1,190 @@
+package containers
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+type ResetMode int
+
+const (
+	// ResetDeleteAll deletes every entity through the admin api.
+	ResetDeleteAll ResetMode = iota
+	// ResetTruncate truncates the entity tables in postgres. It is faster than deleting every entity but bypasses the
+	// admin api, so kong does not invalidate the entities it may have cached for proxying.
+	ResetTruncate
+)
+
+// resetCollections are deleted in order, entities that depend on others are deleted before them and the entities
+// below upstreams, certificates and consumers are deleted with them.
+var resetCollections = []string{"plugins", "routes", "services", "upstreams", "certificates", "consumers"}
+
+// truncateTables are the community edition tables holding entities, the ones that do not exist in the running kong
+// version are skipped.
+var truncateTables = []string{
+	"plugins", "routes", "services", "targets", "upstreams", "snis", "certificates", "ca_certificates",
+	"keyauth_credentials", "basicauth_credentials", "hmacauth_credentials", "jwt_secrets", "acls",
+	"oauth2_tokens", "oauth2_authorization_codes", "oauth2_credentials", "consumers",
+}
+
+// ResetKong removes every service, route, consumer, plugin, upstream and certificate from the kong admin api
+// listening on hostAddress.
+func ResetKong(hostAddress string) error {
+	client := &http.Client{}
+	hostAddress = strings.TrimRight(hostAddress, "/")
+
+	for _, collection := range resetCollections {
+		for {
+			ids, err := listIds(client, hostAddress, collection)
+			if err != nil {
+				return err
+			}
+			if len(ids) == 0 {
+				break
+			}
+			for _, id := range ids {
+				if err := deleteEntity(client, hostAddress, collection, id); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// emptyDeclarativeConfig replaces every entity of a kong running without a database.
+const emptyDeclarativeConfig = "_format_version: \"1.1\"\n"
+
+// Reset removes every entity from kong so each test starts from an empty kong. Without a database kong is reset by
+// loading an empty declarative config, whatever the mode.
+func (testContext *TestContext) Reset(mode ResetMode) error {
+	if testContext.Database == DatabaseOff {
+		return LoadDeclarativeConfig(testContext.KongHostAddress, []byte(emptyDeclarativeConfig))
+	}
+
+	if mode == ResetDeleteAll {
+		return ResetKong(testContext.KongHostAddress)
+	}
+
+	if testContext.postgres == nil {
+		return fmt.Errorf("could not truncate kong tables, kong is not running with postgres")
+	}
+
+	return truncateKong(testContext.postgres.ConnectionString)
+}
+
+// LoadDeclarativeConfig replaces the entities of a kong running without a database with the ones in a declarative
+// config file.
+func (testContext *TestContext) LoadDeclarativeConfig(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("could not read declarative config %s, error: %v", path, err)
+	}
+	return LoadDeclarativeConfig(testContext.KongHostAddress, data)
+}
+
+// LoadDeclarativeConfig posts a declarative config to the /config endpoint of the kong admin api listening on
+// hostAddress, which is only available when kong runs without a database.
+func LoadDeclarativeConfig(hostAddress string, config []byte) error {
+	body, err := json.Marshal(map[string]string{"config": string(config)})
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.Post(strings.TrimRight(hostAddress, "/")+"/config", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("could not load declarative config, error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		message, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("could not load declarative config, message from kong: %s", message)
+	}
+
+	return nil
+}
+
+// Fixtures returns a fixture builder for the kong of the test context. Kong running without a database does not
+// accept changes through the admin api, use LoadDeclarativeConfig with it instead.
+func (testContext *TestContext) Fixtures() *FixtureBuilder {
+	return NewFixtureBuilder(testContext.KongHostAddress)
+}
+
+func listIds(client *http.Client, hostAddress string, collection string) ([]string, error) {
+	resp, err := client.Get(fmt.Sprintf("%s/%s?size=1000", hostAddress, collection))
+	if err != nil {
+		return nil, fmt.Errorf("could not list %s, error: %v", collection, err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not list %s, message from kong: %s", collection, body)
+	}
+
+	page := &struct {
+		Data []struct {
+			Id string `json:"id"`
+		} `json:"data"`
+	}{}
+	if err := json.Unmarshal(body, page); err != nil {
+		return nil, fmt.Errorf("could not parse %s list response, error: %v", collection, err)
+	}
+
+	ids := make([]string, 0, len(page.Data))
+	for _, entity := range page.Data {
+		ids = append(ids, entity.Id)
+	}
+	return ids, nil
+}
+
+func truncateKong(connectionString string) error {
+	db, err := sql.Open("postgres", connectionString)
+	if err != nil {
+		return fmt.Errorf("could not connect to postgres, error: %v", err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query("SELECT table_name FROM information_schema.tables WHERE table_schema = current_schema()")
+	if err != nil {
+		return fmt.Errorf("could not list kong tables, error: %v", err)
+	}
+	defer rows.Close()
+
+	existing := map[string]bool{}
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			return fmt.Errorf("could not list kong tables, error: %v", err)
+		}
+		existing[table] = true
+	}
+
+	tables := make([]string, 0, len(truncateTables))
+	for _, table := range truncateTables {
+		if existing[table] {
+			tables = append(tables, pq.QuoteIdentifier(table))
+		}
+	}
+	if len(tables) == 0 {
+		return nil
+	}
+
+	if _, err := db.Exec(fmt.Sprintf("TRUNCATE %s CASCADE", strings.Join(tables, ", "))); err != nil {
+		return fmt.Errorf("could not truncate kong tables, error: %v", err)
+	}
+
+	return nil
+}
@@ -7,12 +7,37 @@ import (
 	"github.com/ory/dockertest"
 )
 
+const (
+	DatabasePostgres  = "postgres"
+	DatabaseCassandra = "cassandra"
+	DatabaseOff       = "off"
+)
+
 type TestContext struct {
 	containers      []container
+	postgres        *postgresContainer
 	KongHostAddress string
+	Database        string
+	// KongVersion is the version reported by the running kong, e.g. 1.4.3.
+	KongVersion string
+}
+
+// StartOptions configures the kong started by StartKongWithOptions.
+type StartOptions struct {
+	KongVersion string
+	// DockerfilePath builds kong from a dockerfile instead of running the official image of KongVersion.
+	DockerfilePath string
+	// Database is where kong stores its entities, DatabasePostgres when empty.
+	Database string
+	// DeclarativeConfigPath is the declarative config file loaded by kong when Database is DatabaseOff.
+	DeclarativeConfigPath string
 }
 
 func StartKong(kongVersion, dockerfilePath string) *TestContext {
+	return StartKongWithOptions(StartOptions{KongVersion: kongVersion, DockerfilePath: dockerfilePath})
+}
+
+func StartKongWithOptions(options StartOptions) *TestContext {
 	log.SetOutput(os.Stdout)
 
 	var err error
@@ -21,16 +46,37 @@ func StartKong(kongVersion, dockerfilePath string) *TestContext {
 		log.Fatalf("Could not connect to docker: %s", err)
 	}
 
-	postgres := NewPostgresContainer(pool)
+	testContext := &TestContext{Database: options.Database}
+
+	var database kongDatabase
+	switch options.Database {
+	case "", DatabasePostgres:
+		testContext.Database = DatabasePostgres
+		testContext.postgres = NewPostgresContainer(pool)
+		database = testContext.postgres
+	case DatabaseCassandra:
+		database = NewCassandraContainer(pool)
+	case DatabaseOff:
+	default:
+		log.Fatalf("Unsupported kong database: %s", options.Database)
+	}
+
+	if database != nil {
+		testContext.containers = append(testContext.containers, database)
+	}
+
+	kong := newKongContainer(pool, database, options.KongVersion, options.DockerfilePath, options.DeclarativeConfigPath)
+	testContext.containers = append(testContext.containers, kong)
+	testContext.KongHostAddress = kong.HostAddress
 
-	var kong *kongContainer
-	if dockerfilePath == "" {
-		kong = NewKongContainer(pool, postgres, kongVersion)
-	} else {
-		kong = NewKongContainerDockerfile(pool, postgres, dockerfilePath)
+	testContext.KongVersion = options.KongVersion
+	if version, err := kongVersion(kong.HostAddress); err == nil && version != "" {
+		testContext.KongVersion = version
+	} else if err != nil {
+		log.Printf("Could not get kong version: %v", err)
 	}
 
-	return &TestContext{containers: []container{postgres, kong}, KongHostAddress: kong.HostAddress}
+	return testContext
 }
 
 func StopKong(testContext *TestContext) {
@@ -0,0 +1,104 @@
+package containers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a kong version, e.g. 1.4.3 or 1.5.0.2 for kong enterprise.
+type Version []int
+
+// ParseVersion parses the numeric part of a kong version or docker image tag. Anything after the first dash, like
+// -alpine, -beta or -enterprise-edition, is ignored.
+func ParseVersion(version string) (Version, error) {
+	numbers := strings.TrimPrefix(strings.SplitN(strings.TrimSpace(version), "-", 2)[0], "v")
+	if numbers == "" {
+		return nil, fmt.Errorf("invalid kong version %q", version)
+	}
+
+	parts := strings.Split(numbers, ".")
+	result := make(Version, len(parts))
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 {
+			return nil, fmt.Errorf("invalid kong version %q", version)
+		}
+		result[i] = number
+	}
+
+	return result, nil
+}
+
+// Compare returns -1, 0 or 1 when v is lower than, equal to or greater than other. Missing parts count as 0, so 1.4
+// equals 1.4.0.
+func (v Version) Compare(other Version) int {
+	for i := 0; i < len(v) || i < len(other); i++ {
+		var a, b int
+		if i < len(v) {
+			a = v[i]
+		}
+		if i < len(other) {
+			b = other[i]
+		}
+		if a < b {
+			return -1
+		}
+		if a > b {
+			return 1
+		}
+	}
+	return 0
+}
+
+func (v Version) String() string {
+	parts := make([]string, len(v))
+	for i, number := range v {
+		parts[i] = strconv.Itoa(number)
+	}
+	return strings.Join(parts, ".")
+}
+
+// VersionSatisfies reports whether a kong version satisfies a constraint made of comparisons separated by commas, which
+// must all hold, e.g. ">= 1.3, < 2.0". The supported operators are =, !=, >, >=, < and <=.
+func VersionSatisfies(version string, constraint string) (bool, error) {
+	v, err := ParseVersion(version)
+	if err != nil {
+		return false, err
+	}
+
+	for _, comparison := range strings.Split(constraint, ",") {
+		comparison = strings.TrimSpace(comparison)
+
+		operator := strings.TrimRight(comparison, "0123456789.-abcdefghijklmnopqrstuvwxyz ")
+		operand, err := ParseVersion(strings.TrimSpace(comparison[len(operator):]))
+		if err != nil {
+			return false, fmt.Errorf("invalid version constraint %q, error: %v", constraint, err)
+		}
+
+		result := v.Compare(operand)
+		var ok bool
+		switch strings.TrimSpace(operator) {
+		case "", "=", "==":
+			ok = result == 0
+		case "!=":
+			ok = result != 0
+		case ">":
+			ok = result > 0
+		case ">=":
+			ok = result >= 0
+		case "<":
+			ok = result < 0
+		case "<=":
+			ok = result <= 0
+		default:
+			return false, fmt.Errorf("invalid version constraint %q, unknown operator %q", constraint, operator)
+		}
+
+		if !ok {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
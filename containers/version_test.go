@@ -0,0 +1,56 @@
+package containers_test
+
+import (
+	"testing"
+
+	"github.com/globocom/gokong/containers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVersion(t *testing.T) {
+	for version, expected := range map[string]containers.Version{
+		"1.4":                         {1, 4},
+		"1.4.3-alpine":                {1, 4, 3},
+		"1.5.0.2-enterprise-edition":  {1, 5, 0, 2},
+		"2.0.0-beta1":                 {2, 0, 0},
+		" 0.36-2-enterprise-edition ": {0, 36},
+	} {
+		result, err := containers.ParseVersion(version)
+
+		assert.Nil(t, err, version)
+		assert.Equal(t, expected, result, version)
+	}
+
+	for _, version := range []string{"", "latest", "1.x"} {
+		_, err := containers.ParseVersion(version)
+
+		assert.NotNil(t, err, version)
+	}
+}
+
+func TestVersionSatisfies(t *testing.T) {
+	for _, c := range []struct {
+		version    string
+		constraint string
+		expected   bool
+	}{
+		{"1.4.3", ">= 1.3", true},
+		{"1.4", "= 1.4.0", true},
+		{"1.4", "1.4", true},
+		{"1.2.2", ">= 1.3", false},
+		{"1.4.3", ">= 1.3, < 2.0", true},
+		{"2.0.1", ">= 1.3, < 2.0", false},
+		{"1.5.0.2-enterprise-edition", "> 1.5", true},
+		{"1.3", "!= 1.3", false},
+		{"1.3", "<= 1.3.0", true},
+	} {
+		result, err := containers.VersionSatisfies(c.version, c.constraint)
+
+		assert.Nil(t, err)
+		assert.Equal(t, c.expected, result, "%s %s", c.version, c.constraint)
+	}
+
+	_, err := containers.VersionSatisfies("1.4", "~> 1.3")
+
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,314 @@
+package gokong
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type EventHooksClient interface {
+	Create(eventHookRequest *EventHookRequest) (*EventHook, error)
+	GetById(id string) (*EventHook, error)
+	List(query *EventHookQueryString) ([]*EventHook, error)
+	UpdateById(id string, eventHookRequest *EventHookRequest) (*EventHook, error)
+	DeleteById(id string) error
+	ListSources() (EventHookSources, error)
+	Ping(id string) (*EventHookResult, error)
+	Test(id string, data map[string]interface{}) (*EventHookResult, error)
+}
+
+type eventHooksClient struct {
+	config *Config
+}
+
+// The handlers an event hook can run.
+const (
+	EventHookHandlerWebhook       = "webhook"
+	EventHookHandlerWebhookCustom = "webhook-custom"
+	EventHookHandlerLog           = "log"
+	EventHookHandlerLambda        = "lambda"
+)
+
+// EventHookRequest runs a handler when kong fires the event of a source, e.g. the create event of the crud source.
+// Event is optional, an event hook without an event runs for all the events of its source. Snooze is the number of
+// seconds during which repeated events are ignored and OnChange runs the handler only when the event data changes.
+type EventHookRequest struct {
+	Source   string           `json:"source,omitempty" yaml:"source,omitempty"`
+	Event    string           `json:"event,omitempty" yaml:"event,omitempty"`
+	Handler  string           `json:"handler,omitempty" yaml:"handler,omitempty"`
+	OnChange *bool            `json:"on_change,omitempty" yaml:"on_change,omitempty"`
+	Snooze   *int             `json:"snooze,omitempty" yaml:"snooze,omitempty"`
+	Config   *EventHookConfig `json:"config,omitempty" yaml:"config,omitempty"`
+}
+
+// EventHookConfig configures the handler of an event hook, each handler uses its own fields:
+//
+// webhook posts the event data as json to Url, with Headers, signed with Secret when it is set.
+//
+// webhook-custom sends Body, or Payload when Body is not set, to Url with Method, Headers and Query. Body and the
+// values of Payload are templates that are rendered with the event data when PayloadFormat is set.
+//
+// log logs the event and has no config.
+//
+// lambda runs Functions, lua functions that are given the event data.
+type EventHookConfig struct {
+	Url           string            `json:"url,omitempty" yaml:"url,omitempty"`
+	Method        string            `json:"method,omitempty" yaml:"method,omitempty"`
+	Headers       map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Query         map[string]string `json:"query,omitempty" yaml:"query,omitempty"`
+	Secret        string            `json:"secret,omitempty" yaml:"secret,omitempty"`
+	SslVerify     *bool             `json:"ssl_verify,omitempty" yaml:"ssl_verify,omitempty"`
+	Body          string            `json:"body,omitempty" yaml:"body,omitempty"`
+	Payload       map[string]string `json:"payload,omitempty" yaml:"payload,omitempty"`
+	PayloadFormat *bool             `json:"payload_format,omitempty" yaml:"payload_format,omitempty"`
+	Functions     []string          `json:"functions,omitempty" yaml:"functions,omitempty"`
+}
+
+type EventHook struct {
+	Id        string           `json:"id,omitempty" yaml:"id,omitempty"`
+	Source    string           `json:"source,omitempty" yaml:"source,omitempty"`
+	Event     string           `json:"event,omitempty" yaml:"event,omitempty"`
+	Handler   string           `json:"handler,omitempty" yaml:"handler,omitempty"`
+	OnChange  bool             `json:"on_change,omitempty" yaml:"on_change,omitempty"`
+	Snooze    int              `json:"snooze,omitempty" yaml:"snooze,omitempty"`
+	Config    *EventHookConfig `json:"config,omitempty" yaml:"config,omitempty"`
+	CreatedAt int              `json:"created_at,omitempty" yaml:"created_at,omitempty"`
+}
+
+type EventHooks struct {
+	Data   []*EventHook `json:"data,omitempty" yaml:"data,omitempty"`
+	Next   string       `json:"next,omitempty" yaml:"next,omitempty"`
+	Offset string       `json:"offset,omitempty" yaml:"offset,omitempty"`
+}
+
+type EventHookQueryString struct {
+	Offset string `json:"offset,omitempty"`
+	Size   int    `json:"size"`
+}
+
+// EventHookSources are the events of each source kong can fire, keyed by source and event name.
+type EventHookSources map[string]map[string]*EventHookEvent
+
+// EventHookEvent describes an event, Fields are the fields of its data and Unique the fields snooze and on_change
+// compare events by.
+type EventHookEvent struct {
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Fields      []string `json:"fields,omitempty" yaml:"fields,omitempty"`
+	Unique      []string `json:"unique,omitempty" yaml:"unique,omitempty"`
+}
+
+// EventHookResult is the outcome of running the handler of an event hook with Ping or Test: the event data the
+// handler was given and, for webhooks, the response of the webhook.
+type EventHookResult struct {
+	Data   map[string]interface{} `json:"data,omitempty" yaml:"data,omitempty"`
+	Result *EventHookResponse     `json:"result,omitempty" yaml:"result,omitempty"`
+}
+
+type EventHookResponse struct {
+	Status  int                    `json:"status,omitempty" yaml:"status,omitempty"`
+	Headers map[string]interface{} `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Body    interface{}            `json:"body,omitempty" yaml:"body,omitempty"`
+}
+
+const EventHooksPath = "/event-hooks/"
+
+func (eventHooksClient *eventHooksClient) Create(eventHookRequest *EventHookRequest) (*EventHook, error) {
+	r, body, errs := newPost(eventHooksClient.config, EventHooksPath).Send(eventHookRequest).End()
+	if errs != nil {
+		return nil, fmt.Errorf("could not create new event hook, error: %v", errs)
+	}
+
+	if r.StatusCode == 400 {
+		return nil, fmt.Errorf("bad request, message from kong: %s", body)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	createdEventHook := &EventHook{}
+	err := json.Unmarshal([]byte(body), createdEventHook)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse event hook creation response, error: %v", err)
+	}
+
+	if createdEventHook.Id == "" {
+		return nil, fmt.Errorf("could not create event hook, error: %v", body)
+	}
+
+	return createdEventHook, nil
+}
+
+func (eventHooksClient *eventHooksClient) GetById(id string) (*EventHook, error) {
+	r, body, errs := newGet(eventHooksClient.config, EventHooksPath+id).End()
+	if errs != nil {
+		return nil, fmt.Errorf("could not get event hook, error: %v", errs)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	eventHook := &EventHook{}
+	err := json.Unmarshal([]byte(body), eventHook)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse event hook get response, error: %v", err)
+	}
+
+	if eventHook.Id == "" {
+		return nil, nil
+	}
+
+	return eventHook, nil
+}
+
+func (eventHooksClient *eventHooksClient) List(query *EventHookQueryString) ([]*EventHook, error) {
+	eventHooks := make([]*EventHook, 0)
+
+	pageQuery := EventHookQueryString{}
+	if query != nil {
+		pageQuery = *query
+	}
+
+	if pageQuery.Size < 100 {
+		pageQuery.Size = 100
+	}
+
+	if pageQuery.Size > 1000 {
+		pageQuery.Size = 1000
+	}
+
+	for {
+		data := &EventHooks{}
+
+		r, body, errs := newGet(eventHooksClient.config, EventHooksPath).Query(pageQuery).End()
+		if errs != nil {
+			return nil, fmt.Errorf("could not get event hooks, error: %v", errs)
+		}
+
+		if r.StatusCode == 401 || r.StatusCode == 403 {
+			return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+		}
+
+		err := json.Unmarshal([]byte(body), data)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse event hooks list response, error: %v", err)
+		}
+
+		eventHooks = append(eventHooks, data.Data...)
+		if data.Next == "" || data.Offset == "" {
+			break
+		}
+
+		pageQuery.Offset = data.Offset
+	}
+
+	return eventHooks, nil
+}
+
+func (eventHooksClient *eventHooksClient) UpdateById(id string, eventHookRequest *EventHookRequest) (*EventHook, error) {
+	r, body, errs := newPatch(eventHooksClient.config, EventHooksPath+id).Send(eventHookRequest).End()
+	if errs != nil {
+		return nil, fmt.Errorf("could not update event hook, error: %v", errs)
+	}
+
+	if r.StatusCode == 400 {
+		return nil, fmt.Errorf("bad request, message from kong: %s", body)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	updatedEventHook := &EventHook{}
+	err := json.Unmarshal([]byte(body), updatedEventHook)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse event hook update response, error: %v", err)
+	}
+
+	if updatedEventHook.Id == "" {
+		return nil, fmt.Errorf("could not update event hook, error: %v", body)
+	}
+
+	return updatedEventHook, nil
+}
+
+func (eventHooksClient *eventHooksClient) DeleteById(id string) error {
+	r, body, errs := newDelete(eventHooksClient.config, EventHooksPath+id).End()
+	if errs != nil {
+		return fmt.Errorf("could not delete event hook, result: %v error: %v", r, errs)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	return nil
+}
+
+// ListSources returns the sources and events kong can run event hooks for.
+func (eventHooksClient *eventHooksClient) ListSources() (EventHookSources, error) {
+	r, body, errs := newGet(eventHooksClient.config, EventHooksPath+"sources").End()
+	if errs != nil {
+		return nil, fmt.Errorf("could not get event hook sources, error: %v", errs)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	sources := &struct {
+		Data EventHookSources `json:"data"`
+	}{}
+	err := json.Unmarshal([]byte(body), sources)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse event hook sources response, error: %v", err)
+	}
+
+	return sources.Data, nil
+}
+
+// Ping runs the handler of a webhook event hook with a ping event, to check that the webhook can be reached.
+func (eventHooksClient *eventHooksClient) Ping(id string) (*EventHookResult, error) {
+	r, body, errs := newGet(eventHooksClient.config, EventHooksPath+id+"/ping").End()
+	if errs != nil {
+		return nil, fmt.Errorf("could not ping event hook, error: %v", errs)
+	}
+
+	return parseEventHookResult(r.StatusCode, body, "ping")
+}
+
+// Test runs the handler of an event hook with data as the event data, without kong firing the event.
+func (eventHooksClient *eventHooksClient) Test(id string, data map[string]interface{}) (*EventHookResult, error) {
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+
+	r, body, errs := newPost(eventHooksClient.config, EventHooksPath+id+"/test").Send(data).End()
+	if errs != nil {
+		return nil, fmt.Errorf("could not test event hook, error: %v", errs)
+	}
+
+	return parseEventHookResult(r.StatusCode, body, "test")
+}
+
+func parseEventHookResult(statusCode int, body string, action string) (*EventHookResult, error) {
+	if statusCode == 400 {
+		return nil, fmt.Errorf("bad request, message from kong: %s", body)
+	}
+
+	if statusCode == 401 || statusCode == 403 {
+		return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	if statusCode == 404 {
+		return nil, fmt.Errorf("could not %s event hook, error: %v", action, body)
+	}
+
+	result := &EventHookResult{}
+	err := json.Unmarshal([]byte(body), result)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse event hook %s response, error: %v", action, err)
+	}
+
+	return result, nil
+}
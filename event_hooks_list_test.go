@@ -0,0 +1,32 @@
+package gokong
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_EventHooksListFollowsPagesFromACopyOfTheQuery(t *testing.T) {
+	client, queries := pagedClient(
+		`{"data":[{"id":"1"}],"next":"/event-hooks?offset=page-2","offset":"page-2"}`,
+		`{"data":[{"id":"2"}],"next":"/event-hooks?offset=page-3"}`,
+	)
+	query := &EventHookQueryString{Size: 10}
+
+	eventHooks, err := client.EventHooks().List(query)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []*EventHook{{Id: "1"}, {Id: "2"}}, eventHooks)
+	assert.Equal(t, []string{"size=100", "offset=page-2&size=100"}, *queries)
+	assert.Equal(t, &EventHookQueryString{Size: 10}, query)
+}
+
+func Test_EventHooksListAcceptsANilQuery(t *testing.T) {
+	client, queries := pagedClient(`{"data":[{"id":"1"}]}`)
+
+	eventHooks, err := client.EventHooks().List(nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []*EventHook{{Id: "1"}}, eventHooks)
+	assert.Equal(t, []string{"size=100"}, *queries)
+}
@@ -0,0 +1,125 @@
+// +build all enterprise
+
+package gokong
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// eventHooksVersions are the kong enterprise versions with event hooks.
+const eventHooksVersions = ">= 2.1"
+
+func Test_EventHooksClient_LogHandler(t *testing.T) {
+	kongTestContext.SkipUnlessVersion(t, eventHooksVersions)
+
+	client := NewClient(NewDefaultConfig())
+	eventHookRequest := &EventHookRequest{
+		Source:  "crud",
+		Event:   "consumers",
+		Handler: EventHookHandlerLog,
+		Config:  &EventHookConfig{},
+	}
+
+	createdEventHook, err := client.EventHooks().Create(eventHookRequest)
+	assert.Nil(t, err)
+	assert.NotNil(t, createdEventHook)
+	assert.Equal(t, "crud", createdEventHook.Source)
+	assert.Equal(t, "consumers", createdEventHook.Event)
+	assert.Equal(t, EventHookHandlerLog, createdEventHook.Handler)
+
+	result, err := client.EventHooks().GetById(createdEventHook.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, createdEventHook.Id, result.Id)
+
+	eventHooks, err := client.EventHooks().List(&EventHookQueryString{})
+	assert.Nil(t, err)
+	assert.Contains(t, eventHooks, result)
+
+	updatedEventHook, err := client.EventHooks().UpdateById(createdEventHook.Id, &EventHookRequest{Snooze: Int(30)})
+	assert.Nil(t, err)
+	assert.Equal(t, 30, updatedEventHook.Snooze)
+	assert.Equal(t, "consumers", updatedEventHook.Event)
+
+	testResult, err := client.EventHooks().Test(createdEventHook.Id, map[string]interface{}{"operation": "create"})
+	assert.Nil(t, err)
+	assert.NotNil(t, testResult)
+
+	err = client.EventHooks().DeleteById(createdEventHook.Id)
+	assert.Nil(t, err)
+
+	result, err = client.EventHooks().GetById(createdEventHook.Id)
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+}
+
+func Test_EventHooksClient_WebhookCustomHandler(t *testing.T) {
+	kongTestContext.SkipUnlessVersion(t, eventHooksVersions)
+
+	client := NewClient(NewDefaultConfig())
+	eventHookRequest := &EventHookRequest{
+		Source:  "crud",
+		Event:   "services",
+		Handler: EventHookHandlerWebhookCustom,
+		Config: &EventHookConfig{
+			Url:           "http://example.com/hooks",
+			Method:        "POST",
+			Headers:       map[string]string{"content-type": "application/json"},
+			Payload:       map[string]string{"text": "service {{ entity.name }} was changed"},
+			PayloadFormat: Bool(true),
+		},
+	}
+
+	createdEventHook, err := client.EventHooks().Create(eventHookRequest)
+	assert.Nil(t, err)
+	assert.NotNil(t, createdEventHook)
+	assert.Equal(t, "http://example.com/hooks", createdEventHook.Config.Url)
+	assert.Equal(t, "POST", createdEventHook.Config.Method)
+	assert.Equal(t, eventHookRequest.Config.Payload, createdEventHook.Config.Payload)
+
+	err = client.EventHooks().DeleteById(createdEventHook.Id)
+	assert.Nil(t, err)
+}
+
+func Test_EventHooksClient_LambdaHandler(t *testing.T) {
+	kongTestContext.SkipUnlessVersion(t, eventHooksVersions)
+
+	client := NewClient(NewDefaultConfig())
+	eventHookRequest := &EventHookRequest{
+		Source:  "crud",
+		Handler: EventHookHandlerLambda,
+		Config: &EventHookConfig{
+			Functions: []string{"return function (data, event, source, pid) kong.log.notice(event) end"},
+		},
+	}
+
+	createdEventHook, err := client.EventHooks().Create(eventHookRequest)
+	assert.Nil(t, err)
+	assert.NotNil(t, createdEventHook)
+	assert.Equal(t, eventHookRequest.Config.Functions, createdEventHook.Config.Functions)
+
+	err = client.EventHooks().DeleteById(createdEventHook.Id)
+	assert.Nil(t, err)
+}
+
+func Test_EventHooksClient_CreateInvalidEventHook(t *testing.T) {
+	kongTestContext.SkipUnlessVersion(t, eventHooksVersions)
+
+	client := NewClient(NewDefaultConfig())
+
+	createdEventHook, err := client.EventHooks().Create(&EventHookRequest{Source: "crud", Handler: "unknown-handler"})
+	assert.NotNil(t, err)
+	assert.Nil(t, createdEventHook)
+}
+
+func Test_EventHooksClient_ListSources(t *testing.T) {
+	kongTestContext.SkipUnlessVersion(t, eventHooksVersions)
+
+	client := NewClient(NewDefaultConfig())
+
+	sources, err := client.EventHooks().ListSources()
+	assert.Nil(t, err)
+	assert.Contains(t, sources, "crud")
+	assert.Contains(t, sources["crud"], "consumers")
+}
@@ -0,0 +1,374 @@
+package gokongtest
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type foreignKey struct {
+	field     string
+	reference string
+	required  bool
+	cascade   bool
+}
+
+type schema struct {
+	name        string
+	endpointKey string
+	unique      [][]string
+	foreignKeys []foreignKey
+	timestamps  bool
+	defaults    func() map[string]interface{}
+	prepare     func(entity map[string]interface{})
+	validate    func(entity map[string]interface{}) map[string]string
+}
+
+func (s *schema) foreignKey(field string) *foreignKey {
+	for i := range s.foreignKeys {
+		if s.foreignKeys[i].field == field {
+			return &s.foreignKeys[i]
+		}
+	}
+	return nil
+}
+
+var schemas = map[string]*schema{
+	"services": {
+		name:        "services",
+		endpointKey: "name",
+		unique:      [][]string{{"name"}},
+		timestamps:  true,
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"name":               nil,
+				"protocol":           "http",
+				"host":               nil,
+				"port":               80,
+				"path":               nil,
+				"retries":            5,
+				"connect_timeout":    60000,
+				"write_timeout":      60000,
+				"read_timeout":       60000,
+				"client_certificate": nil,
+				"tags":               nil,
+			}
+		},
+		prepare:  expandServiceUrl,
+		validate: requireFields("host"),
+	},
+	"routes": {
+		name:        "routes",
+		endpointKey: "name",
+		unique:      [][]string{{"name"}},
+		foreignKeys: []foreignKey{{field: "service", reference: "services"}},
+		timestamps:  true,
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"name":                       nil,
+				"protocols":                  []interface{}{"http", "https"},
+				"methods":                    nil,
+				"hosts":                      nil,
+				"paths":                      nil,
+				"headers":                    nil,
+				"https_redirect_status_code": 426,
+				"regex_priority":             0,
+				"strip_path":                 true,
+				"preserve_host":              false,
+				"snis":                       nil,
+				"sources":                    nil,
+				"destinations":               nil,
+				"service":                    nil,
+				"tags":                       nil,
+			}
+		},
+		validate: validateRoute,
+	},
+	"consumers": {
+		name:        "consumers",
+		endpointKey: "username",
+		unique:      [][]string{{"username"}, {"custom_id"}},
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"username":  nil,
+				"custom_id": nil,
+				"tags":      nil,
+			}
+		},
+		validate: func(entity map[string]interface{}) map[string]string {
+			if isNull(entity["username"]) && isNull(entity["custom_id"]) {
+				return map[string]string{"@entity": "at least one of these fields must be non-empty: 'custom_id', 'username'"}
+			}
+			return nil
+		},
+	},
+	"plugins": {
+		name:   "plugins",
+		unique: [][]string{{"name", "consumer", "service", "route"}},
+		foreignKeys: []foreignKey{
+			{field: "consumer", reference: "consumers", cascade: true},
+			{field: "service", reference: "services", cascade: true},
+			{field: "route", reference: "routes", cascade: true},
+		},
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"name":      nil,
+				"consumer":  nil,
+				"service":   nil,
+				"route":     nil,
+				"config":    map[string]interface{}{},
+				"enabled":   true,
+				"run_on":    "first",
+				"protocols": []interface{}{"grpc", "grpcs", "http", "https"},
+				"tags":      nil,
+			}
+		},
+		validate: requireFields("name"),
+	},
+	"upstreams": {
+		name:        "upstreams",
+		endpointKey: "name",
+		unique:      [][]string{{"name"}},
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"name":                 nil,
+				"algorithm":            "round-robin",
+				"slots":                10000,
+				"hash_on":              "none",
+				"hash_fallback":        "none",
+				"hash_on_header":       nil,
+				"hash_fallback_header": nil,
+				"hash_on_cookie":       nil,
+				"hash_on_cookie_path":  "/",
+				"host_header":          nil,
+				"client_certificate":   nil,
+				"healthchecks":         defaultHealthChecks(),
+				"tags":                 nil,
+			}
+		},
+		validate: requireFields("name"),
+	},
+	"targets": {
+		name:        "targets",
+		endpointKey: "target",
+		foreignKeys: []foreignKey{{field: "upstream", reference: "upstreams", required: true, cascade: true}},
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"target":   nil,
+				"weight":   100,
+				"upstream": nil,
+				"tags":     nil,
+			}
+		},
+		prepare: func(entity map[string]interface{}) {
+			if target, ok := entity["target"].(string); ok && target != "" && !strings.Contains(target, ":") {
+				entity["target"] = target + ":8000"
+			}
+		},
+		validate: requireFields("target"),
+	},
+	"certificates": {
+		name: "certificates",
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"cert": nil,
+				"key":  nil,
+				"tags": nil,
+			}
+		},
+		validate: requireFields("cert", "key"),
+	},
+	"snis": {
+		name:        "snis",
+		endpointKey: "name",
+		unique:      [][]string{{"name"}},
+		foreignKeys: []foreignKey{{field: "certificate", reference: "certificates", required: true, cascade: true}},
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"name":        nil,
+				"certificate": nil,
+				"tags":        nil,
+			}
+		},
+		validate: requireFields("name"),
+	},
+	"workspaces": {
+		name:        "workspaces",
+		endpointKey: "name",
+		unique:      [][]string{{"name"}},
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"name":    nil,
+				"comment": nil,
+				"config":  map[string]interface{}{},
+				"meta":    map[string]interface{}{},
+			}
+		},
+		validate: requireFields("name"),
+	},
+}
+
+// nestedCollections maps a parent collection to the collections that can be reached below it and the field of the
+// child that refers to the parent, e.g. /services/{service}/routes.
+var nestedCollections = map[string]map[string]string{
+	"services":     {"routes": "service", "plugins": "service"},
+	"routes":       {"plugins": "route"},
+	"consumers":    {"plugins": "consumer"},
+	"upstreams":    {"targets": "upstream"},
+	"certificates": {"snis": "certificate"},
+}
+
+// credentialSchema describes the entities created below a consumer by plugins such as key-auth or basic-auth.
+func credentialSchema(name string) *schema {
+	return &schema{
+		name:        name,
+		foreignKeys: []foreignKey{{field: "consumer", reference: "consumers", required: true, cascade: true}},
+		defaults: func() map[string]interface{} {
+			defaults := map[string]interface{}{
+				"consumer": nil,
+				"tags":     nil,
+			}
+			if name == "key-auth" {
+				defaults["key"] = strings.Replace(newId(), "-", "", -1)
+			}
+			return defaults
+		},
+	}
+}
+
+func defaultHealthChecks() map[string]interface{} {
+	return map[string]interface{}{
+		"active": map[string]interface{}{
+			"type":                     "http",
+			"concurrency":              10,
+			"http_path":                "/",
+			"https_sni":                nil,
+			"https_verify_certificate": true,
+			"timeout":                  1,
+			"healthy": map[string]interface{}{
+				"http_statuses": []interface{}{200, 302},
+				"interval":      0,
+				"successes":     0,
+			},
+			"unhealthy": map[string]interface{}{
+				"http_failures": 0,
+				"http_statuses": []interface{}{429, 404, 500, 501, 502, 503, 504, 505},
+				"interval":      0,
+				"tcp_failures":  0,
+				"timeouts":      0,
+			},
+		},
+		"passive": map[string]interface{}{
+			"type": "http",
+			"healthy": map[string]interface{}{
+				"http_statuses": []interface{}{200, 201, 202, 203, 204, 205, 206, 207, 208, 226, 300, 301, 302, 303, 304, 305, 306, 307, 308},
+				"successes":     0,
+			},
+			"unhealthy": map[string]interface{}{
+				"http_failures": 0,
+				"http_statuses": []interface{}{429, 500, 503},
+				"tcp_failures":  0,
+				"timeouts":      0,
+			},
+		},
+	}
+}
+
+func requireFields(fields ...string) func(entity map[string]interface{}) map[string]string {
+	return func(entity map[string]interface{}) map[string]string {
+		errs := map[string]string{}
+		for _, field := range fields {
+			if isNull(entity[field]) {
+				errs[field] = "required field missing"
+			} else if _, ok := entity[field].(string); !ok {
+				errs[field] = "expected a string"
+			}
+		}
+		if len(errs) == 0 {
+			return nil
+		}
+		return errs
+	}
+}
+
+func validateRoute(entity map[string]interface{}) map[string]string {
+	for _, field := range []string{"methods", "hosts", "paths", "headers", "snis", "sources", "destinations"} {
+		if !isNull(entity[field]) {
+			return nil
+		}
+	}
+	return map[string]string{"@entity": "must set one of 'methods', 'hosts', 'headers', 'paths' when 'protocols' is 'http'"}
+}
+
+func expandServiceUrl(entity map[string]interface{}) {
+	raw, ok := entity["url"].(string)
+	delete(entity, "url")
+	if !ok || raw == "" {
+		return
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return
+	}
+
+	entity["protocol"] = u.Scheme
+	entity["host"] = u.Hostname()
+	if u.Port() != "" {
+		port, _ := strconv.Atoi(u.Port())
+		entity["port"] = port
+	} else if u.Scheme == "https" {
+		entity["port"] = 443
+	} else {
+		entity["port"] = 80
+	}
+	if u.Path != "" {
+		entity["path"] = u.Path
+	} else {
+		entity["path"] = nil
+	}
+}
+
+func isNull(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	switch v := value.(type) {
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+func foreignId(value interface{}) string {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if id, ok := v["id"].(string); ok {
+			return id
+		}
+	case string:
+		return v
+	}
+	return ""
+}
+
+func describeUnique(fields []string, entity map[string]interface{}) string {
+	parts := make([]string, 0, len(fields))
+	for _, field := range fields {
+		parts = append(parts, fmt.Sprintf("%s=%v", field, describeValue(entity[field])))
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+func describeValue(value interface{}) string {
+	if value == nil {
+		return "null"
+	}
+	if id := foreignId(value); id != "" {
+		return fmt.Sprintf("{id=%q}", id)
+	}
+	return fmt.Sprintf("%q", fmt.Sprint(value))
+}
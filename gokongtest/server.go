@@ -0,0 +1,716 @@
+// Package gokongtest provides an in-memory implementation of the Kong Admin API for use in tests.
+//
+// The server keeps services, routes, consumers, plugins, upstreams, targets, certificates, snis and workspaces in
+// memory and mimics kong's pagination, uniqueness and foreign key behaviour, so code using gokong can be tested
+// without running kong:
+//
+//	server := gokongtest.NewServer()
+//	defer server.Close()
+//
+//	client := gokong.NewClient(&gokong.Config{HostAddress: server.URL})
+package gokongtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+)
+
+const DefaultVersion = "1.4.0"
+
+type Server struct {
+	*httptest.Server
+	Version string
+	store   *store
+	nodeId  string
+}
+
+var rootCollections = map[string]bool{
+	"services":     true,
+	"routes":       true,
+	"consumers":    true,
+	"plugins":      true,
+	"upstreams":    true,
+	"certificates": true,
+	"snis":         true,
+}
+
+// NewServer starts a fake Kong Admin API server. The caller should call Close when finished with it.
+func NewServer() *Server {
+	server := &Server{
+		Version: DefaultVersion,
+		store:   newStore(),
+		nodeId:  newId(),
+	}
+	server.Server = httptest.NewServer(server)
+	return server
+}
+
+// Reset removes every entity from the server, leaving only the default workspace.
+func (server *Server) Reset() {
+	server.store.mutex.Lock()
+	defer server.store.mutex.Unlock()
+
+	server.store.reset()
+}
+
+func (server *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	server.store.mutex.Lock()
+	defer server.store.mutex.Unlock()
+
+	segments := splitPath(r.URL.Path)
+	workspace := defaultWorkspace
+	if len(segments) > 0 && !rootCollections[segments[0]] && segments[0] != "workspaces" && segments[0] != "status" {
+		if !server.store.workspaceExists(segments[0]) {
+			writeError(w, notFound())
+			return
+		}
+		workspace = segments[0]
+		segments = segments[1:]
+	}
+
+	input, err := readInput(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	request := &request{
+		method:    r.Method,
+		workspace: workspace,
+		segments:  segments,
+		query:     r.URL.Query(),
+		input:     input,
+	}
+
+	status, body, err := server.route(request)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, status, body)
+}
+
+type request struct {
+	method    string
+	workspace string
+	segments  []string
+	query     url.Values
+	input     map[string]interface{}
+}
+
+func (server *Server) route(r *request) (int, interface{}, *apiError) {
+	s := r.segments
+	switch {
+	case len(s) == 0:
+		if r.method != http.MethodGet {
+			return 0, nil, methodNotAllowed()
+		}
+		return http.StatusOK, server.information(), nil
+
+	case s[0] == "status":
+		if len(s) != 1 || r.method != http.MethodGet {
+			return 0, nil, methodNotAllowed()
+		}
+		return http.StatusOK, server.status(), nil
+
+	case s[0] == "workspaces":
+		return server.routeWorkspaces(r)
+
+	case len(s) == 1:
+		return server.collection(r, s[0], nil)
+
+	case len(s) == 2:
+		entity := server.store.find(r.workspace, s[0], s[1])
+		return server.entity(r, entity)
+	}
+
+	parent := server.store.find(r.workspace, s[0], s[1])
+	if parent == nil {
+		return 0, nil, notFound()
+	}
+
+	if s[0] == "upstreams" {
+		return server.routeUpstream(r, parent, s[2:])
+	}
+
+	if s[0] == "routes" && s[2] == "service" && len(s) == 3 {
+		service := server.store.find(r.workspace, "services", foreignId(parent.data["service"]))
+		return server.entity(r, service)
+	}
+
+	child := s[2]
+	field, nested := nestedCollections[s[0]][child]
+	if !nested {
+		if s[0] != "consumers" || schemas[child] != nil {
+			return 0, nil, notFound()
+		}
+		field = "consumer"
+	}
+
+	if len(s) == 3 {
+		return server.collection(r, child, &parentReference{field: field, id: parent.id()})
+	}
+
+	entity := server.store.find(r.workspace, child, s[3])
+	if entity == nil || foreignId(entity.data[field]) != parent.id() || len(s) > 4 {
+		return 0, nil, notFound()
+	}
+	return server.entity(r, entity)
+}
+
+type parentReference struct {
+	field string
+	id    string
+}
+
+func (server *Server) collection(r *request, collection string, parent *parentReference) (int, interface{}, *apiError) {
+	switch r.method {
+	case http.MethodGet:
+		var filter func(*record) bool
+		if parent != nil {
+			filter = func(record *record) bool { return foreignId(record.data[parent.field]) == parent.id }
+		}
+		return server.page(r, "/"+strings.Join(r.segments, "/"), server.store.list(r.workspace, collection, filter), nil)
+
+	case http.MethodPost:
+		if parent != nil {
+			r.input[parent.field] = map[string]interface{}{"id": parent.id}
+		}
+		created, err := server.store.insert(r.workspace, collection, r.input)
+		if err != nil {
+			return 0, nil, err
+		}
+		return http.StatusCreated, created.data, nil
+	}
+
+	return 0, nil, methodNotAllowed()
+}
+
+func (server *Server) entity(r *request, entity *record) (int, interface{}, *apiError) {
+	switch r.method {
+	case http.MethodGet:
+		if entity == nil {
+			return 0, nil, notFound()
+		}
+		return http.StatusOK, entity.data, nil
+
+	case http.MethodPatch:
+		if entity == nil {
+			return 0, nil, notFound()
+		}
+		updated, err := server.store.update(r.workspace, entity, r.input)
+		if err != nil {
+			return 0, nil, err
+		}
+		return http.StatusOK, updated.data, nil
+
+	case http.MethodPut:
+		if entity == nil {
+			collection := r.segments[len(r.segments)-2]
+			key := r.segments[len(r.segments)-1]
+			if sc := server.store.schema(collection); sc.endpointKey != "" && !looksLikeId(key) {
+				r.input[sc.endpointKey] = key
+			} else {
+				r.input["id"] = key
+			}
+			created, err := server.store.insert(r.workspace, collection, r.input)
+			if err != nil {
+				return 0, nil, err
+			}
+			return http.StatusOK, created.data, nil
+		}
+		replacement := merge(server.store.schema(entity.collection).defaults(), r.input)
+		updated, err := server.store.update(r.workspace, entity, replacement)
+		if err != nil {
+			return 0, nil, err
+		}
+		return http.StatusOK, updated.data, nil
+
+	case http.MethodDelete:
+		if entity == nil {
+			return http.StatusNoContent, nil, nil
+		}
+		if err := server.store.remove(entity); err != nil {
+			return 0, nil, err
+		}
+		return http.StatusNoContent, nil, nil
+	}
+
+	return 0, nil, methodNotAllowed()
+}
+
+func (server *Server) page(r *request, path string, records []*record, render func(*record) interface{}) (int, interface{}, *apiError) {
+	records = filterTags(records, r.query.Get("tags"))
+
+	size := 100
+	if r.query.Get("size") != "" {
+		var ok bool
+		size, ok = toInt(r.query.Get("size"))
+		if !ok || size < 1 || size > 1000 {
+			return 0, nil, badRequest("size must be an integer between 1 and 1000")
+		}
+	}
+
+	p, err := paginate(records, size, r.query.Get("offset"))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	data := make([]interface{}, 0, len(p.records))
+	for _, record := range p.records {
+		if render != nil {
+			data = append(data, render(record))
+		} else {
+			data = append(data, record.data)
+		}
+	}
+
+	body := map[string]interface{}{"data": data, "next": nil}
+	if p.offset != "" {
+		next := url.Values{"offset": {p.offset}}
+		if r.query.Get("size") != "" {
+			next.Set("size", r.query.Get("size"))
+		}
+		if r.query.Get("tags") != "" {
+			next.Set("tags", r.query.Get("tags"))
+		}
+		body["next"] = path + "?" + next.Encode()
+		body["offset"] = p.offset
+	}
+
+	return http.StatusOK, body, nil
+}
+
+func (server *Server) routeUpstream(r *request, upstream *record, s []string) (int, interface{}, *apiError) {
+	switch {
+	case s[0] == "health" && len(s) == 1:
+		if r.method != http.MethodGet {
+			return 0, nil, methodNotAllowed()
+		}
+		return server.upstreamHealth(r, upstream)
+
+	case s[0] != "targets":
+		return 0, nil, notFound()
+
+	case len(s) == 1:
+		switch r.method {
+		case http.MethodGet:
+			return server.page(r, "/"+strings.Join(r.segments, "/"), server.store.activeTargets(upstream.id()), nil)
+		case http.MethodPost:
+			r.input["upstream"] = map[string]interface{}{"id": upstream.id()}
+			created, err := server.store.insert(r.workspace, "targets", r.input)
+			if err != nil {
+				return 0, nil, err
+			}
+			return http.StatusCreated, created.data, nil
+		}
+		return 0, nil, methodNotAllowed()
+
+	case s[1] == "all" && len(s) == 2:
+		if r.method != http.MethodGet {
+			return 0, nil, methodNotAllowed()
+		}
+		all := server.store.list("", "targets", func(record *record) bool {
+			return foreignId(record.data["upstream"]) == upstream.id()
+		})
+		return server.page(r, "/"+strings.Join(r.segments, "/"), all, renderHistoricalTarget)
+	}
+
+	target := server.store.findTarget(upstream.id(), s[1])
+	if target == nil {
+		return 0, nil, notFound()
+	}
+
+	switch len(s) {
+	case 2:
+		if r.method != http.MethodDelete {
+			return 0, nil, methodNotAllowed()
+		}
+		_, err := server.store.insert(r.workspace, "targets", map[string]interface{}{
+			"target":   target.data["target"],
+			"weight":   0,
+			"upstream": map[string]interface{}{"id": upstream.id()},
+		})
+		if err != nil {
+			return 0, nil, err
+		}
+		return http.StatusNoContent, nil, nil
+
+	case 3, 4:
+		health := strings.ToUpper(s[len(s)-1])
+		if r.method != http.MethodPost || (health != "HEALTHY" && health != "UNHEALTHY") {
+			return 0, nil, notFound()
+		}
+		address, ok := target.data["target"].(string)
+		if !ok {
+			return 0, nil, badRequest("target has no address")
+		}
+		key := upstream.id() + "|" + address
+		if len(s) == 4 {
+			key += "|" + s[2]
+		}
+		server.store.health[key] = health
+		return http.StatusNoContent, nil, nil
+	}
+
+	return 0, nil, notFound()
+}
+
+func (server *Server) upstreamHealth(r *request, upstream *record) (int, interface{}, *apiError) {
+	targets := server.store.activeTargets(upstream.id())
+
+	if r.query.Get("balancer_health") == "1" || r.query.Get("balancer_health") == "true" {
+		health := "HEALTHY"
+		unhealthy := 0
+		for _, target := range targets {
+			if server.targetHealth(upstream.id(), target) == "UNHEALTHY" {
+				unhealthy++
+			}
+		}
+		if len(targets) > 0 && unhealthy == len(targets) {
+			health = "UNHEALTHY"
+		}
+		return http.StatusOK, map[string]interface{}{
+			"data":    map[string]interface{}{"id": upstream.id(), "health": health},
+			"next":    nil,
+			"node_id": server.nodeId,
+		}, nil
+	}
+
+	status, body, err := server.page(r, "/"+strings.Join(r.segments, "/"), targets, func(target *record) interface{} {
+		data := copyMap(target.data)
+		health := server.targetHealth(upstream.id(), target)
+		address, _ := target.data["target"].(string)
+		host, port := splitHostPort(address)
+		addressHealth := health
+		if h, ok := server.store.health[upstream.id()+"|"+address+"|"+address]; ok {
+			addressHealth = h
+		}
+		weight, _ := toInt(target.data["weight"])
+		data["health"] = health
+		data["data"] = map[string]interface{}{
+			"addresses": []interface{}{
+				map[string]interface{}{"ip": host, "port": port, "health": addressHealth, "weight": weight},
+			},
+			"dns":        "A",
+			"nodeWeight": weight,
+			"weight":     map[string]interface{}{"available": weight, "total": weight, "unavailable": 0},
+		}
+		return data
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	body.(map[string]interface{})["node_id"] = server.nodeId
+	return status, body, nil
+}
+
+func (server *Server) targetHealth(upstreamId string, target *record) string {
+	address, ok := target.data["target"].(string)
+	if !ok {
+		return "HEALTHCHECKS_OFF"
+	}
+	if health, ok := server.store.health[upstreamId+"|"+address]; ok {
+		return health
+	}
+	if health, ok := server.store.health[upstreamId+"|"+address+"|"+address]; ok {
+		return health
+	}
+	return "HEALTHCHECKS_OFF"
+}
+
+func (server *Server) routeWorkspaces(r *request) (int, interface{}, *apiError) {
+	s := r.segments
+	if r.workspace != defaultWorkspace && len(s) > 1 {
+		return 0, nil, notFound()
+	}
+
+	if len(s) == 1 {
+		return server.collection(r, "workspaces", nil)
+	}
+
+	workspace := server.store.find("", "workspaces", s[1])
+	if len(s) == 2 {
+		if workspace != nil && r.method == http.MethodDelete {
+			name, ok := workspace.data["name"].(string)
+			if !ok {
+				return 0, nil, badRequest("workspace has no name")
+			}
+			if name == defaultWorkspace {
+				return 0, nil, badRequest("Cannot delete default workspace")
+			}
+			for collection := range server.store.records {
+				if collection != "workspaces" && len(server.store.list(name, collection, nil)) > 0 {
+					return 0, nil, badRequest("Workspace is not empty")
+				}
+			}
+		}
+		return server.entity(r, workspace)
+	}
+
+	if workspace == nil || s[2] != "entities" || len(s) > 4 {
+		return 0, nil, notFound()
+	}
+	name, ok := workspace.data["name"].(string)
+	if !ok {
+		return 0, nil, badRequest("workspace has no name")
+	}
+
+	if len(s) == 4 {
+		entity := server.findAnyEntity(name, s[3])
+		if entity == nil {
+			return 0, nil, notFound()
+		}
+		switch r.method {
+		case http.MethodGet:
+			return http.StatusOK, workspaceEntity(workspace, entity), nil
+		case http.MethodDelete:
+			removeWorkspace(entity, name)
+			return http.StatusNoContent, nil, nil
+		}
+		return 0, nil, methodNotAllowed()
+	}
+
+	switch r.method {
+	case http.MethodGet:
+		data := make([]interface{}, 0)
+		for collection := range server.store.records {
+			if collection == "workspaces" {
+				continue
+			}
+			for _, entity := range server.store.list(name, collection, nil) {
+				if entityType := r.query.Get("entity_type"); entityType != "" && entityType != collection {
+					continue
+				}
+				data = append(data, workspaceEntity(workspace, entity))
+			}
+		}
+		return http.StatusOK, map[string]interface{}{"data": data, "total": len(data), "next": nil}, nil
+
+	case http.MethodPost:
+		added := make([]interface{}, 0)
+		for _, id := range entityIds(r.input["entities"]) {
+			entity := server.findAnyEntity("", id)
+			if entity == nil {
+				return 0, nil, badRequest(fmt.Sprintf("entity %s does not exist", id))
+			}
+			if !entity.inWorkspace(name) {
+				entity.workspaces = append(entity.workspaces, name)
+			}
+			added = append(added, entity.data)
+		}
+		return http.StatusCreated, added, nil
+
+	case http.MethodDelete:
+		for _, id := range entityIds(r.input["entities"]) {
+			if entity := server.findAnyEntity(name, id); entity != nil {
+				removeWorkspace(entity, name)
+			}
+		}
+		return http.StatusNoContent, nil, nil
+	}
+
+	return 0, nil, methodNotAllowed()
+}
+
+func (server *Server) findAnyEntity(workspace string, id string) *record {
+	for collection, records := range server.store.records {
+		if collection == "workspaces" {
+			continue
+		}
+		if entity, ok := records[id]; ok && (workspace == "" || entity.inWorkspace(workspace)) {
+			return entity
+		}
+	}
+	return nil
+}
+
+func (server *Server) information() map[string]interface{} {
+	return map[string]interface{}{
+		"version":  server.Version,
+		"tagline":  "Welcome to kong",
+		"hostname": "gokongtest",
+		"node_id":  server.nodeId,
+		"configuration": map[string]interface{}{
+			"database": "postgres",
+		},
+	}
+}
+
+func (server *Server) status() map[string]interface{} {
+	return map[string]interface{}{
+		"database": map[string]interface{}{"reachable": true},
+		"server": map[string]interface{}{
+			"total_requests":       0,
+			"connections_active":   1,
+			"connections_accepted": 1,
+			"connections_handled":  1,
+			"connections_reading":  0,
+			"connections_writing":  1,
+			"connections_waiting":  0,
+		},
+	}
+}
+
+func workspaceEntity(workspace *record, entity *record) map[string]interface{} {
+	uniqueFieldName := "id"
+	uniqueFieldValue := entity.id()
+	if key := schemas[entity.collection]; key != nil && key.endpointKey != "" {
+		if value, ok := entity.data[key.endpointKey].(string); ok && value != "" {
+			uniqueFieldName = key.endpointKey
+			uniqueFieldValue = value
+		}
+	}
+	return map[string]interface{}{
+		"workspace_id":       workspace.id(),
+		"workspace_name":     workspace.data["name"],
+		"entity_id":          entity.id(),
+		"entity_type":        entity.collection,
+		"unique_field_name":  uniqueFieldName,
+		"unique_field_value": uniqueFieldValue,
+	}
+}
+
+func removeWorkspace(entity *record, workspace string) {
+	workspaces := make([]string, 0, len(entity.workspaces))
+	for _, w := range entity.workspaces {
+		if w != workspace {
+			workspaces = append(workspaces, w)
+		}
+	}
+	entity.workspaces = workspaces
+}
+
+func renderHistoricalTarget(target *record) interface{} {
+	data := copyMap(target.data)
+	data["upstream_id"] = foreignId(data["upstream"])
+	delete(data, "upstream")
+	return data
+}
+
+func filterTags(records []*record, tags string) []*record {
+	if tags == "" {
+		return records
+	}
+
+	matchAny := strings.Contains(tags, "/")
+	wanted := strings.FieldsFunc(tags, func(c rune) bool { return c == ',' || c == '/' })
+
+	result := make([]*record, 0, len(records))
+	for _, r := range records {
+		entityTags := map[string]bool{}
+		if list, ok := r.data["tags"].([]interface{}); ok {
+			for _, tag := range list {
+				entityTags[fmt.Sprint(tag)] = true
+			}
+		}
+
+		matches := 0
+		for _, tag := range wanted {
+			if entityTags[tag] {
+				matches++
+			}
+		}
+		if (matchAny && matches > 0) || (!matchAny && matches == len(wanted)) {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+func entityIds(value interface{}) []string {
+	ids := make([]string, 0)
+	switch v := value.(type) {
+	case string:
+		for _, id := range strings.Split(v, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				ids = append(ids, id)
+			}
+		}
+	case []interface{}:
+		for _, id := range v {
+			ids = append(ids, fmt.Sprint(id))
+		}
+	}
+	return ids
+}
+
+func splitPath(path string) []string {
+	segments := make([]string, 0)
+	for _, segment := range strings.Split(path, "/") {
+		if segment != "" {
+			if unescaped, err := url.PathUnescape(segment); err == nil {
+				segment = unescaped
+			}
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+func splitHostPort(address string) (string, int) {
+	index := strings.LastIndex(address, ":")
+	if index < 0 {
+		return address, 8000
+	}
+	port, _ := toInt(address[index+1:])
+	return address[:index], port
+}
+
+func looksLikeId(key string) bool {
+	return len(key) == 36 && strings.Count(key, "-") == 4
+}
+
+func readInput(r *http.Request) (map[string]interface{}, *apiError) {
+	input := map[string]interface{}{}
+	if r.Body == nil {
+		return input, nil
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, badRequest(fmt.Sprintf("could not read request body: %v", err))
+	}
+
+	trimmed := strings.TrimSpace(string(body))
+	if trimmed == "" || trimmed == `""` {
+		return input, nil
+	}
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		values, err := url.ParseQuery(trimmed)
+		if err != nil {
+			return nil, badRequest(fmt.Sprintf("could not parse request body: %v", err))
+		}
+		for key, value := range values {
+			input[key] = value[0]
+		}
+		return input, nil
+	}
+
+	if err := json.Unmarshal(body, &input); err != nil {
+		return nil, badRequest(fmt.Sprintf("Cannot parse JSON body: %v", err))
+	}
+	return input, nil
+}
+
+func writeError(w http.ResponseWriter, err *apiError) {
+	writeJSON(w, err.status, err.body)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	if status == http.StatusNoContent {
+		w.WriteHeader(status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
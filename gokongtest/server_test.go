@@ -0,0 +1,436 @@
+package gokongtest_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/globocom/gokong"
+	"github.com/globocom/gokong/gokongtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func newClient(server *gokongtest.Server) gokong.KongAdminClient {
+	return gokong.NewClient(&gokong.Config{HostAddress: server.URL})
+}
+
+func TestServer_Status(t *testing.T) {
+	server := gokongtest.NewServer()
+	defer server.Close()
+
+	status, err := newClient(server).Status().Get()
+
+	assert.Nil(t, err)
+	assert.True(t, status.Database.Reachable)
+}
+
+func TestServer_ServicesCrud(t *testing.T) {
+	server := gokongtest.NewServer()
+	defer server.Close()
+	client := newClient(server)
+
+	created, err := client.Services().Create(&gokong.ServiceRequest{
+		Name: gokong.String("service-1"),
+		Url:  gokong.String("https://example.com/api"),
+	})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, created)
+	assert.Equal(t, "https", *created.Protocol)
+	assert.Equal(t, "example.com", *created.Host)
+	assert.Equal(t, 443, *created.Port)
+	assert.Equal(t, "/api", *created.Path)
+
+	result, err := client.Services().GetServiceByName("service-1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, created, result)
+
+	updated, err := client.Services().UpdateServiceById(*created.Id, &gokong.ServiceRequest{
+		Name:     gokong.String("service-1"),
+		Protocol: gokong.String("http"),
+		Host:     gokong.String("example.org"),
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "example.org", *updated.Host)
+	assert.Equal(t, "/api", *updated.Path)
+
+	err = client.Services().DeleteServiceByName("service-1")
+
+	assert.Nil(t, err)
+
+	result, err = client.Services().GetServiceById(*created.Id)
+
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+}
+
+func TestServer_UniqueViolation(t *testing.T) {
+	server := gokongtest.NewServer()
+	defer server.Close()
+	client := newClient(server)
+
+	_, err := client.Consumers().Create(&gokong.ConsumerRequest{Username: "user"})
+	assert.Nil(t, err)
+
+	result, err := client.Consumers().Create(&gokong.ConsumerRequest{Username: "user"})
+
+	assert.Nil(t, result)
+	assert.NotNil(t, err)
+}
+
+func TestServer_RequiredFieldsMustBeStrings(t *testing.T) {
+	server := gokongtest.NewServer()
+	defer server.Close()
+	client := newClient(server)
+
+	_, err := client.Upstreams().Create(&gokong.UpstreamRequest{Name: "upstream"})
+	assert.Nil(t, err)
+
+	resp, err := http.Post(server.URL+"/upstreams/upstream/targets", "application/json", strings.NewReader(`{"target": 8080}`))
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(resp.Body)
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Contains(t, string(body), "target: expected a string")
+
+	health, err := client.Upstreams().GetHealthByName("upstream")
+
+	assert.Nil(t, err)
+	assert.Len(t, health.Targets, 0)
+}
+
+func TestServer_Pagination(t *testing.T) {
+	server := gokongtest.NewServer()
+	defer server.Close()
+	client := newClient(server)
+
+	for i := 0; i < 250; i++ {
+		_, err := client.Consumers().Create(&gokong.ConsumerRequest{Username: fmt.Sprintf("user-%d", i)})
+		assert.Nil(t, err)
+	}
+
+	results, err := client.Consumers().List(&gokong.ConsumerQueryString{})
+
+	assert.Nil(t, err)
+	assert.Len(t, results, 250)
+	assert.Equal(t, "user-0", results[0].Username)
+	assert.Equal(t, "user-249", results[249].Username)
+}
+
+func TestServer_UpstreamsPagination(t *testing.T) {
+	server := gokongtest.NewServer()
+	defer server.Close()
+	client := newClient(server)
+
+	for i := 0; i < 150; i++ {
+		_, err := client.Upstreams().Create(&gokong.UpstreamRequest{Name: fmt.Sprintf("upstream-%d", i)})
+		assert.Nil(t, err)
+	}
+
+	firstPage, err := client.Upstreams().List()
+
+	assert.Nil(t, err)
+	assert.Len(t, firstPage.Results, 100)
+
+	results, err := client.Upstreams().ListWithQuery(nil)
+
+	assert.Nil(t, err)
+	assert.Len(t, results, 150)
+	assert.Equal(t, "upstream-149", results[149].Name)
+}
+
+func TestServer_ForeignKeys(t *testing.T) {
+	server := gokongtest.NewServer()
+	defer server.Close()
+	client := newClient(server)
+
+	route, err := client.Routes().Create(&gokong.RouteRequest{
+		Paths:   gokong.StringSlice([]string{"/"}),
+		Service: gokong.ToId("3cb9e2a2-2a2a-4f3e-9b8f-07a1a7c4b6f1"),
+	})
+
+	assert.Nil(t, route)
+	assert.NotNil(t, err)
+
+	service, err := client.Services().Create(&gokong.ServiceRequest{
+		Name: gokong.String("service"),
+		Host: gokong.String("example.com"),
+	})
+	assert.Nil(t, err)
+
+	route, err = client.Routes().Create(&gokong.RouteRequest{
+		Name:    gokong.String("route"),
+		Paths:   gokong.StringSlice([]string{"/"}),
+		Service: gokong.ToId(*service.Id),
+	})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, route)
+
+	routes, err := client.Routes().GetRoutesFromServiceName("service")
+
+	assert.Nil(t, err)
+	assert.Len(t, routes, 1)
+
+	err = client.Services().DeleteServiceById(*service.Id)
+
+	assert.NotNil(t, err)
+
+	err = client.Routes().DeleteById(*route.Id)
+	assert.Nil(t, err)
+
+	err = client.Services().DeleteServiceById(*service.Id)
+	assert.Nil(t, err)
+}
+
+func TestServer_PluginsCascadeWithConsumer(t *testing.T) {
+	server := gokongtest.NewServer()
+	defer server.Close()
+	client := newClient(server)
+
+	consumer, err := client.Consumers().Create(&gokong.ConsumerRequest{Username: "user"})
+	assert.Nil(t, err)
+
+	plugin, err := client.Plugins().Create(&gokong.PluginRequest{
+		Name:       "rate-limiting",
+		ConsumerId: gokong.ToId(consumer.Id),
+		Config:     map[string]interface{}{"minute": 10},
+	})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, plugin)
+	assert.Equal(t, consumer.Id, gokong.IdToString(plugin.ConsumerId))
+
+	duplicate, err := client.Plugins().Create(&gokong.PluginRequest{
+		Name:       "rate-limiting",
+		ConsumerId: gokong.ToId(consumer.Id),
+	})
+
+	assert.Nil(t, duplicate)
+	assert.NotNil(t, err)
+
+	err = client.Consumers().DeleteById(consumer.Id)
+	assert.Nil(t, err)
+
+	result, err := client.Plugins().GetById(plugin.Id)
+
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+}
+
+func TestServer_UpstreamTargetsAndHealth(t *testing.T) {
+	server := gokongtest.NewServer()
+	defer server.Close()
+	client := newClient(server)
+
+	upstream, err := client.Upstreams().Create(&gokong.UpstreamRequest{Name: "upstream"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 10000, upstream.Slots)
+	assert.Equal(t, "round-robin", upstream.Algorithm)
+
+	target, err := client.Targets().CreateFromUpstreamName("upstream", &gokong.TargetRequest{Target: "10.0.0.1:80", Weight: 100})
+	assert.Nil(t, err)
+
+	_, err = client.Targets().CreateFromUpstreamName("upstream", &gokong.TargetRequest{Target: "10.0.0.1:80", Weight: 50})
+	assert.Nil(t, err)
+
+	targets, err := client.Targets().GetTargetsFromUpstreamName("upstream")
+
+	assert.Nil(t, err)
+	assert.Len(t, targets, 1)
+	assert.Equal(t, 50, *targets[0].Weight)
+
+	err = client.Upstreams().SetAddressAsUnhealthy("upstream", "10.0.0.1:80", "10.0.0.1:80")
+	assert.Nil(t, err)
+
+	health, err := client.Upstreams().GetHealthByName("upstream")
+
+	assert.Nil(t, err)
+	assert.Equal(t, upstream.Id, health.Id)
+	assert.Equal(t, "UNHEALTHY", health.Health)
+	assert.Len(t, health.Targets, 1)
+	assert.Equal(t, "UNHEALTHY", health.Targets[0].Data.Addresses[0].Health)
+
+	err = client.Targets().DeleteFromUpstreamByHostPort("upstream", *target.Target)
+	assert.Nil(t, err)
+
+	targets, err = client.Targets().GetTargetsFromUpstreamName("upstream")
+
+	assert.Nil(t, err)
+	assert.Len(t, targets, 0)
+
+	all, err := client.Targets().GetAllTargetsFromUpstreamName("upstream")
+
+	assert.Nil(t, err)
+	assert.Len(t, all, 3)
+	assert.Equal(t, upstream.Id, gokong.IdToString(all[0].Upstream))
+}
+
+func TestServer_CertificatesAndSnis(t *testing.T) {
+	server := gokongtest.NewServer()
+	defer server.Close()
+	client := newClient(server)
+
+	certificate, err := client.Certificates().Create(&gokong.CertificateRequest{
+		Cert: gokong.String("cert"),
+		Key:  gokong.String("key"),
+	})
+	assert.Nil(t, err)
+
+	sni, err := client.Snis().Create(&gokong.SnisRequest{
+		Name:          "example.com",
+		CertificateId: gokong.ToId(*certificate.Id),
+	})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, sni)
+
+	err = client.Certificates().DeleteById(*certificate.Id)
+	assert.Nil(t, err)
+
+	result, err := client.Snis().GetByName("example.com")
+
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+}
+
+func TestServer_Workspaces(t *testing.T) {
+	server := gokongtest.NewServer()
+	defer server.Close()
+	client := newClient(server)
+
+	workspace, err := client.Workspaces().Create(&gokong.WorkspaceRequest{Name: gokong.String("team-a")})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, workspace)
+
+	teamClient := gokong.NewClient(&gokong.Config{HostAddress: server.URL, Workspace: "team-a"})
+	service, err := teamClient.Services().Create(&gokong.ServiceRequest{
+		Name: gokong.String("service"),
+		Host: gokong.String("example.com"),
+	})
+	assert.Nil(t, err)
+
+	result, err := client.Services().GetServiceByName("service")
+
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+
+	entities, err := teamClient.Workspaces().ListEntities()
+
+	assert.Nil(t, err)
+	assert.Len(t, entities, 1)
+	assert.Equal(t, *service.Id, *entities[0].EntityId)
+	assert.Equal(t, "services", *entities[0].EntityType)
+
+	err = teamClient.Workspaces().Delete()
+	assert.NotNil(t, err)
+
+	err = teamClient.Services().DeleteServiceById(*service.Id)
+	assert.Nil(t, err)
+
+	err = teamClient.Workspaces().Delete()
+	assert.Nil(t, err)
+}
+
+func TestServer_WorkspaceEntities(t *testing.T) {
+	server := gokongtest.NewServer()
+	defer server.Close()
+	client := newClient(server)
+
+	workspace, err := client.Workspaces().Create(&gokong.WorkspaceRequest{
+		Name: gokong.String("team-a"),
+		Meta: &gokong.WorkspaceMeta{Color: gokong.String("#1E90FF")},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "#1E90FF", *workspace.Meta.Color)
+
+	service, err := client.Services().Create(&gokong.ServiceRequest{
+		Name: gokong.String("service"),
+		Host: gokong.String("example.com"),
+	})
+	assert.Nil(t, err)
+	consumer, err := client.Consumers().Create(&gokong.ConsumerRequest{Username: "user"})
+	assert.Nil(t, err)
+
+	teamClient := client.InWorkspace("team-a")
+	err = teamClient.Workspaces().AddEntitiesToWorkspace([]string{*service.Id, consumer.Id})
+	assert.Nil(t, err)
+
+	query := &gokong.WorkspaceEntityQueryString{EntityType: "services"}
+	entities, err := teamClient.Workspaces().ListEntitiesWithQuery(query)
+
+	assert.Nil(t, err)
+	assert.Len(t, entities, 1)
+	assert.Equal(t, *service.Id, *entities[0].EntityId)
+	assert.Equal(t, &gokong.WorkspaceEntityQueryString{EntityType: "services"}, query)
+
+	entities, err = teamClient.Workspaces().ListEntitiesWithQuery(nil)
+
+	assert.Nil(t, err)
+	assert.Len(t, entities, 2)
+
+	entity, err := teamClient.Workspaces().GetEntity(consumer.Id)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "consumers", *entity.EntityType)
+	assert.Equal(t, "team-a", *entity.WorkspaceName)
+
+	err = teamClient.Workspaces().DeleteMultipleEntitiesFromWorkspace([]string{*service.Id, consumer.Id})
+	assert.Nil(t, err)
+
+	entity, err = teamClient.Workspaces().GetEntity(consumer.Id)
+
+	assert.Nil(t, err)
+	assert.Nil(t, entity)
+}
+
+func TestServer_Reset(t *testing.T) {
+	server := gokongtest.NewServer()
+	defer server.Close()
+	client := newClient(server)
+
+	_, err := client.Consumers().Create(&gokong.ConsumerRequest{Username: "user"})
+	assert.Nil(t, err)
+
+	server.Reset()
+
+	consumers, err := client.Consumers().List(&gokong.ConsumerQueryString{})
+
+	assert.Nil(t, err)
+	assert.Len(t, consumers, 0)
+}
+
+func TestServer_InWorkspace(t *testing.T) {
+	server := gokongtest.NewServer()
+	defer server.Close()
+	client := newClient(server)
+
+	done := make(chan error)
+	for _, name := range []string{"team-a", "team-b"} {
+		_, err := client.Workspaces().Create(&gokong.WorkspaceRequest{Name: gokong.String(name)})
+		assert.Nil(t, err)
+
+		go func(workspace gokong.KongAdminClient) {
+			_, err := workspace.Consumers().Create(&gokong.ConsumerRequest{Username: "user"})
+			done <- err
+		}(client.InWorkspace(name))
+	}
+	assert.Nil(t, <-done)
+	assert.Nil(t, <-done)
+
+	teamA, err := client.InWorkspace("team-a").Consumers().List(&gokong.ConsumerQueryString{})
+	assert.Nil(t, err)
+	assert.Len(t, teamA, 1)
+
+	defaultConsumers, err := client.Consumers().List(&gokong.ConsumerQueryString{})
+	assert.Nil(t, err)
+	assert.Len(t, defaultConsumers, 0)
+}
@@ -0,0 +1,446 @@
+package gokongtest
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const defaultWorkspace = "default"
+
+type record struct {
+	seq        int64
+	collection string
+	data       map[string]interface{}
+	workspaces []string
+}
+
+func (r *record) id() string {
+	return r.data["id"].(string)
+}
+
+func (r *record) inWorkspace(workspace string) bool {
+	if r.workspaces == nil {
+		return true
+	}
+	for _, w := range r.workspaces {
+		if w == workspace {
+			return true
+		}
+	}
+	return false
+}
+
+type apiError struct {
+	status int
+	body   map[string]interface{}
+}
+
+func notFound() *apiError {
+	return &apiError{status: http.StatusNotFound, body: map[string]interface{}{"message": "Not found"}}
+}
+
+func methodNotAllowed() *apiError {
+	return &apiError{status: http.StatusMethodNotAllowed, body: map[string]interface{}{"message": "Method not allowed"}}
+}
+
+func badRequest(message string) *apiError {
+	return &apiError{status: http.StatusBadRequest, body: map[string]interface{}{"message": message}}
+}
+
+func schemaViolation(fields map[string]string) *apiError {
+	message := "schema violation"
+	keys := make([]string, 0, len(fields))
+	for field := range fields {
+		keys = append(keys, field)
+	}
+	sort.Strings(keys)
+	for i, field := range keys {
+		if i == 0 {
+			message += " ("
+		} else {
+			message += "; "
+		}
+		message += fmt.Sprintf("%s: %s", field, fields[field])
+	}
+	if len(keys) > 0 {
+		message += ")"
+	}
+	return &apiError{status: http.StatusBadRequest, body: map[string]interface{}{
+		"code":    2,
+		"name":    "schema violation",
+		"message": message,
+		"fields":  fields,
+	}}
+}
+
+type store struct {
+	mutex   sync.Mutex
+	seq     int64
+	records map[string]map[string]*record
+	health  map[string]string
+}
+
+func newStore() *store {
+	s := &store{}
+	s.reset()
+	return s
+}
+
+func (s *store) reset() {
+	s.seq = 0
+	s.records = map[string]map[string]*record{}
+	s.health = map[string]string{}
+
+	s.insertRecord("workspaces", nil, map[string]interface{}{
+		"id":         newId(),
+		"name":       defaultWorkspace,
+		"comment":    nil,
+		"created_at": time.Now().Unix(),
+		"config":     map[string]interface{}{},
+		"meta":       map[string]interface{}{},
+	})
+}
+
+func (s *store) schema(collection string) *schema {
+	if sc, ok := schemas[collection]; ok {
+		return sc
+	}
+	return credentialSchema(collection)
+}
+
+func (s *store) insertRecord(collection string, workspaces []string, data map[string]interface{}) *record {
+	s.seq++
+	r := &record{seq: s.seq, collection: collection, data: data, workspaces: workspaces}
+	if s.records[collection] == nil {
+		s.records[collection] = map[string]*record{}
+	}
+	s.records[collection][r.id()] = r
+	return r
+}
+
+func (s *store) workspaceExists(name string) bool {
+	return s.find("", "workspaces", name) != nil
+}
+
+// list returns the records of a collection visible in a workspace, in creation order.
+func (s *store) list(workspace string, collection string, filter func(r *record) bool) []*record {
+	result := make([]*record, 0)
+	for _, r := range s.records[collection] {
+		if workspace != "" && !r.inWorkspace(workspace) {
+			continue
+		}
+		if filter != nil && !filter(r) {
+			continue
+		}
+		result = append(result, r)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].seq < result[j].seq })
+	return result
+}
+
+// find looks up a record by id or by the endpoint key of its collection, e.g. a service by name.
+func (s *store) find(workspace string, collection string, key string) *record {
+	if r, ok := s.records[collection][key]; ok && (workspace == "" || r.inWorkspace(workspace)) {
+		return r
+	}
+	sc := s.schema(collection)
+	if sc.endpointKey == "" {
+		return nil
+	}
+	for _, r := range s.list(workspace, collection, nil) {
+		if value, ok := r.data[sc.endpointKey].(string); ok && value == key {
+			return r
+		}
+	}
+	return nil
+}
+
+func (s *store) insert(workspace string, collection string, input map[string]interface{}) (*record, *apiError) {
+	sc := s.schema(collection)
+	data := merge(sc.defaults(), input)
+
+	if id, ok := data["id"].(string); ok && id != "" {
+		if _, exists := s.records[collection][id]; exists {
+			return nil, &apiError{status: http.StatusConflict, body: map[string]interface{}{
+				"code":    3,
+				"name":    "primary key violation",
+				"message": fmt.Sprintf("primary key violation on key '{id=%q}'", id),
+				"fields":  map[string]interface{}{"id": id},
+			}}
+		}
+	} else {
+		data["id"] = newId()
+	}
+
+	now := time.Now().Unix()
+	data["created_at"] = now
+	if sc.timestamps {
+		data["updated_at"] = now
+	}
+
+	if err := s.check(workspace, sc, data, ""); err != nil {
+		return nil, err
+	}
+
+	var workspaces []string
+	if collection != "workspaces" {
+		workspaces = []string{workspace}
+	}
+	return s.insertRecord(collection, workspaces, data), nil
+}
+
+func (s *store) update(workspace string, r *record, input map[string]interface{}) (*record, *apiError) {
+	sc := s.schema(r.collection)
+	data := merge(copyMap(r.data), input)
+	data["id"] = r.id()
+	data["created_at"] = r.data["created_at"]
+	if sc.timestamps {
+		data["updated_at"] = time.Now().Unix()
+	}
+
+	if err := s.check(workspace, sc, data, r.id()); err != nil {
+		return nil, err
+	}
+
+	r.data = data
+	return r, nil
+}
+
+func (s *store) check(workspace string, sc *schema, data map[string]interface{}, selfId string) *apiError {
+	if sc.prepare != nil {
+		sc.prepare(data)
+	}
+
+	if sc.validate != nil {
+		if fields := sc.validate(data); fields != nil {
+			return schemaViolation(fields)
+		}
+	}
+
+	for _, fk := range sc.foreignKeys {
+		value := data[fk.field]
+		if value == nil {
+			if fk.required {
+				return schemaViolation(map[string]string{fk.field: "required field missing"})
+			}
+			continue
+		}
+
+		key := foreignId(value)
+		if reference, ok := value.(map[string]interface{}); ok && key == "" {
+			if name, ok := reference["name"].(string); ok {
+				key = name
+			}
+		}
+
+		referenced := s.find(workspace, fk.reference, key)
+		if referenced == nil {
+			return &apiError{status: http.StatusBadRequest, body: map[string]interface{}{
+				"code":    4,
+				"name":    "foreign key violation",
+				"message": fmt.Sprintf("the foreign key '{id=%q}' does not reference an existing '%s' entity.", key, fk.reference),
+				"fields":  map[string]interface{}{fk.field: value},
+			}}
+		}
+		data[fk.field] = map[string]interface{}{"id": referenced.id()}
+	}
+
+	for _, fields := range sc.unique {
+		if isNull(data[fields[0]]) {
+			continue
+		}
+		for _, other := range s.list(workspace, sc.name, nil) {
+			if other.id() == selfId {
+				continue
+			}
+			if describeUnique(fields, other.data) == describeUnique(fields, data) {
+				return &apiError{status: http.StatusConflict, body: map[string]interface{}{
+					"code":    5,
+					"name":    "unique constraint violation",
+					"message": fmt.Sprintf("UNIQUE violation detected on '%s'", describeUnique(fields, data)),
+					"fields":  pick(data, fields),
+				}}
+			}
+		}
+	}
+
+	return nil
+}
+
+// remove deletes a record, cascading to the records that refer to it with on delete cascade and refusing to delete
+// it while it is still referred to by records without it.
+func (s *store) remove(r *record) *apiError {
+	for collection, sc := range s.allSchemas() {
+		for _, fk := range sc.foreignKeys {
+			if fk.reference != r.collection || fk.cascade {
+				continue
+			}
+			for _, other := range s.records[collection] {
+				if foreignId(other.data[fk.field]) == r.id() {
+					return &apiError{status: http.StatusBadRequest, body: map[string]interface{}{
+						"code":    4,
+						"name":    "foreign key violation",
+						"message": fmt.Sprintf("an existing '%s' entity references this '%s' entity", collection, r.collection),
+						"fields":  map[string]interface{}{"@referenced_by": collection},
+					}}
+				}
+			}
+		}
+	}
+
+	s.cascade(r)
+	return nil
+}
+
+func (s *store) cascade(r *record) {
+	delete(s.records[r.collection], r.id())
+	for collection, sc := range s.allSchemas() {
+		for _, fk := range sc.foreignKeys {
+			if fk.reference != r.collection || !fk.cascade {
+				continue
+			}
+			for _, other := range s.records[collection] {
+				if foreignId(other.data[fk.field]) == r.id() {
+					s.cascade(other)
+				}
+			}
+		}
+	}
+}
+
+func (s *store) allSchemas() map[string]*schema {
+	result := map[string]*schema{}
+	for collection := range s.records {
+		result[collection] = s.schema(collection)
+	}
+	return result
+}
+
+// activeTargets returns the current targets of an upstream. Like kong before 2.2 every change to a target is stored
+// as a new target entry, so only the latest entry for each target counts and entries with a weight of 0 are hidden.
+func (s *store) activeTargets(upstreamId string) []*record {
+	latest := map[string]*record{}
+	for _, r := range s.list("", "targets", func(r *record) bool { return foreignId(r.data["upstream"]) == upstreamId }) {
+		if target, ok := r.data["target"].(string); ok {
+			latest[target] = r
+		}
+	}
+
+	result := make([]*record, 0)
+	for _, r := range latest {
+		if weight, _ := toInt(r.data["weight"]); weight > 0 {
+			result = append(result, r)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].seq < result[j].seq })
+	return result
+}
+
+func (s *store) findTarget(upstreamId string, key string) *record {
+	for _, r := range s.activeTargets(upstreamId) {
+		if r.id() == key || r.data["target"] == key {
+			return r
+		}
+	}
+	return nil
+}
+
+type page struct {
+	records []*record
+	offset  string
+}
+
+func paginate(records []*record, size int, offset string) (*page, *apiError) {
+	start := 0
+	if offset != "" {
+		decoded, err := base64.StdEncoding.DecodeString(offset)
+		if err != nil {
+			return nil, badRequest("invalid offset")
+		}
+		seq, err := strconv.ParseInt(string(decoded), 10, 64)
+		if err != nil {
+			return nil, badRequest("invalid offset")
+		}
+		start = len(records)
+		for i, r := range records {
+			if r.seq >= seq {
+				start = i
+				break
+			}
+		}
+	}
+
+	end := start + size
+	if end >= len(records) {
+		return &page{records: records[start:]}, nil
+	}
+	return &page{
+		records: records[start:end],
+		offset:  base64.StdEncoding.EncodeToString([]byte(strconv.FormatInt(records[end].seq, 10))),
+	}, nil
+}
+
+// merge applies the fields of src over dst. Nested records such as upstream health checks or plugin configuration
+// are merged field by field, while references to other entities are replaced.
+func merge(dst map[string]interface{}, src map[string]interface{}) map[string]interface{} {
+	for key, value := range src {
+		if srcMap, ok := value.(map[string]interface{}); ok {
+			if dstMap, ok := dst[key].(map[string]interface{}); ok && dstMap["id"] == nil && srcMap["id"] == nil {
+				dst[key] = merge(copyMap(dstMap), srcMap)
+				continue
+			}
+		}
+		dst[key] = value
+	}
+	return dst
+}
+
+func copyMap(src map[string]interface{}) map[string]interface{} {
+	dst := make(map[string]interface{}, len(src))
+	for key, value := range src {
+		if m, ok := value.(map[string]interface{}); ok {
+			value = copyMap(m)
+		}
+		dst[key] = value
+	}
+	return dst
+}
+
+func pick(data map[string]interface{}, fields []string) map[string]interface{} {
+	result := map[string]interface{}{}
+	for _, field := range fields {
+		result[field] = data[field]
+	}
+	return result
+}
+
+func toInt(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case float64:
+		return int(v), true
+	case string:
+		i, err := strconv.Atoi(v)
+		return i, err == nil
+	}
+	return 0, false
+}
+
+func newId() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
@@ -0,0 +1,173 @@
+package gokong
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Instrumentation is notified of every request sent to the kong admin api, e.g. to trace or measure the requests.
+// StartRequest is called before the request is sent, once the limiter of the config lets it through, and the context it
+// returns is the context of the request, which is then passed to EndRequest once the response is read. The status code
+// is 0 when no response was received.
+type Instrumentation interface {
+	StartRequest(ctx context.Context, info *RequestInfo) context.Context
+	EndRequest(ctx context.Context, info *RequestInfo, statusCode int, err error)
+}
+
+// RequestInfo describes a request to the kong admin api. Entity is the collection the request is sent to, e.g.
+// services or rbac.users, and Operation is one of get, list, create, update, upsert or delete, or the action of the
+// request such as health or set_healthy. Both come from a closed set, so they can be used in span names and metric
+// labels. RetryCount is the number of times the request was sent before, gokong sends every request once so it is 0.
+// Header holds the headers of the request, instrumentation may add headers to it, e.g. to propagate a trace.
+type RequestInfo struct {
+	Entity     string
+	Operation  string
+	EntityId   string
+	Workspace  string
+	Method     string
+	Path       string
+	RetryCount int
+	Header     http.Header
+}
+
+// requestEntities are the collections of the kong admin api, a request is described by the last one in its path so
+// that the entity is one of a closed set and ids, names and addresses never take its place.
+var requestEntities = map[string]string{
+	"admins":       "admins",
+	"certificates": "certificates",
+	"consumers":    "consumers",
+	"endpoints":    "endpoints",
+	"entities":     "entities",
+	"event-hooks":  "event-hooks",
+	"plugins":      "plugins",
+	"roles":        "roles",
+	"routes":       "routes",
+	"service":      "services",
+	"services":     "services",
+	"snis":         "snis",
+	"status":       "status",
+	"targets":      "targets",
+	"upstreams":    "upstreams",
+	"workspaces":   "workspaces",
+}
+
+// singularEntities have no id, e.g. the status of kong or the service of a route in /routes/{route}/service.
+var singularEntities = map[string]bool{"service": true, "status": true}
+
+// requestActions are the endpoints that act on an entity instead of being a collection, e.g.
+// /upstreams/{upstream}/health, with the operation they describe.
+var requestActions = map[string]string{
+	"health":    "health",
+	"healthy":   "set_healthy",
+	"unhealthy": "set_unhealthy",
+	"ping":      "ping",
+	"test":      "test",
+}
+
+// requestIdActions are the endpoints found where the id of an entity would be, e.g. /targets/all.
+var requestIdActions = map[string]map[string]string{
+	"admins":      {"register": "register"},
+	"event-hooks": {"sources": "sources"},
+	"targets":     {"all": "list"},
+}
+
+// newRequestInfo describes a request from its method and its path relative to the host address and workspace. The
+// path alternates collections and ids, e.g. /upstreams/{upstream}/targets/{target}, and the last collection is the
+// entity of the request. Segments that are neither, such as the address in
+// /upstreams/{upstream}/targets/{target}/{address}/healthy, are skipped, and the credentials of a consumer are
+// described as the credentials entity whatever their plugin.
+func newRequestInfo(config *Config, method string, path string, header http.Header) *RequestInfo {
+	info := &RequestInfo{
+		Workspace: config.Workspace,
+		Method:    method,
+		Path:      path,
+		Header:    header,
+	}
+
+	if i := strings.Index(path, "?"); i >= 0 {
+		path = path[:i]
+	}
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) > 1 && segments[0] == "rbac" && (segments[1] == "users" || segments[1] == "roles") {
+		info.Entity = "rbac." + segments[1]
+		segments = segments[2:]
+	} else if len(segments) > 0 && requestEntities[segments[0]] != "" {
+		info.Entity = requestEntities[segments[0]]
+		segments = segments[1:]
+	}
+
+	hasId := singularEntities[info.Entity]
+	action := ""
+	expectId := info.Entity != "" && !hasId
+	for i := 0; i < len(segments) && action == "" && segments[i] != ""; i++ {
+		segment := segments[i]
+		switch {
+		case expectId && requestIdActions[info.Entity][segment] != "":
+			action = requestIdActions[info.Entity][segment]
+		case expectId:
+			info.EntityId = segment
+			hasId = true
+			expectId = false
+			// rbac endpoint permissions are identified by a workspace and an endpoint, which contains slashes
+			if info.Entity == "endpoints" {
+				info.EntityId = strings.Join(segments[i:], "/")
+				i = len(segments)
+			}
+		case requestActions[segment] != "":
+			action = requestActions[segment]
+		case requestEntities[segment] != "":
+			info.Entity = requestEntities[segment]
+			info.EntityId = ""
+			hasId = singularEntities[segment]
+			expectId = !hasId
+		case info.Entity == "consumers":
+			info.Entity = "credentials"
+			info.EntityId = ""
+			hasId = false
+			expectId = true
+		}
+	}
+
+	switch {
+	case action != "":
+		info.Operation = action
+	case method == http.MethodGet && hasId:
+		info.Operation = "get"
+	case method == http.MethodGet:
+		info.Operation = "list"
+	case method == http.MethodPost:
+		info.Operation = "create"
+	case method == http.MethodPut:
+		info.Operation = "upsert"
+	case method == http.MethodPatch:
+		info.Operation = "update"
+	case method == http.MethodDelete:
+		info.Operation = "delete"
+	default:
+		info.Operation = strings.ToLower(method)
+	}
+
+	return info
+}
+
+type multiInstrumentation []Instrumentation
+
+// MultiInstrumentation notifies each of the instrumentations in turn, e.g. to both trace and measure the requests.
+func MultiInstrumentation(instrumentations ...Instrumentation) Instrumentation {
+	return multiInstrumentation(instrumentations)
+}
+
+func (instrumentations multiInstrumentation) StartRequest(ctx context.Context, info *RequestInfo) context.Context {
+	for _, instrumentation := range instrumentations {
+		ctx = instrumentation.StartRequest(ctx, info)
+	}
+	return ctx
+}
+
+func (instrumentations multiInstrumentation) EndRequest(ctx context.Context, info *RequestInfo, statusCode int, err error) {
+	for i := len(instrumentations) - 1; i >= 0; i-- {
+		instrumentations[i].EndRequest(ctx, info, statusCode, err)
+	}
+}
@@ -0,0 +1,137 @@
+package gokong
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type contextKey string
+
+type recordingInstrumentation struct {
+	started []*RequestInfo
+	ended   []int
+}
+
+func (instrumentation *recordingInstrumentation) StartRequest(ctx context.Context, info *RequestInfo) context.Context {
+	instrumentation.started = append(instrumentation.started, info)
+	info.Header.Set("traceparent", "trace")
+	return context.WithValue(ctx, contextKey("span"), info.Entity+"."+info.Operation)
+}
+
+func (instrumentation *recordingInstrumentation) EndRequest(ctx context.Context, info *RequestInfo, statusCode int, err error) {
+	instrumentation.ended = append(instrumentation.ended, statusCode)
+}
+
+func Test_RequestNotifiesInstrumentation(t *testing.T) {
+	instrumentation := &recordingInstrumentation{}
+	var sent *http.Request
+	config := &Config{
+		HostAddress:     "http://kong:8001",
+		Instrumentation: instrumentation,
+		HTTPClient: &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			sent = req
+			return &http.Response{
+				StatusCode: 200,
+				Header:     http.Header{},
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"id":"123","name":"service"}`)),
+			}, nil
+		})},
+	}
+	ctx := context.WithValue(context.Background(), contextKey("caller"), "reconciler")
+
+	_, err := NewClient(config).InWorkspace("team-a").WithContext(ctx).Services().UpdateServiceById("123", &ServiceRequest{})
+
+	assert.Nil(t, err)
+	assert.Len(t, instrumentation.started, 1)
+	assert.Equal(t, &RequestInfo{
+		Entity:    "services",
+		Operation: "update",
+		EntityId:  "123",
+		Workspace: "team-a",
+		Method:    "PATCH",
+		Path:      "/services/123",
+		Header:    sent.Header,
+	}, instrumentation.started[0])
+	assert.Equal(t, []int{200}, instrumentation.ended)
+	assert.Equal(t, "reconciler", sent.Context().Value(contextKey("caller")))
+	assert.Equal(t, "services.update", sent.Context().Value(contextKey("span")))
+	assert.Equal(t, "trace", sent.Header.Get("traceparent"))
+}
+
+func Test_WithContextCancelsRequests(t *testing.T) {
+	config := &Config{
+		HostAddress: "http://kong:8001",
+		HTTPClient: &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return nil, req.Context().Err()
+		})},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := NewClient(config).WithContext(ctx).Services().GetServiceByName("service")
+
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "context canceled")
+}
+
+func Test_NewRequestInfo(t *testing.T) {
+	tests := []struct {
+		method    string
+		path      string
+		entity    string
+		operation string
+		entityId  string
+	}{
+		{"GET", "/services/", "services", "list", ""},
+		{"GET", "/services/service", "services", "get", "service"},
+		{"POST", "/services/", "services", "create", ""},
+		{"DELETE", "/consumers/123", "consumers", "delete", "123"},
+		{"GET", "/upstreams/upstream/targets", "targets", "list", ""},
+		{"DELETE", "/upstreams/upstream/targets/target", "targets", "delete", "target"},
+		{"GET", "/plugins/?size=100", "plugins", "list", ""},
+		{"POST", "/rbac/users/", "rbac.users", "create", ""},
+		{"PATCH", "/rbac/roles/role/endpoints/team-a/services/", "endpoints", "update", "team-a/services"},
+		{"GET", "/workspaces/team-a/entities", "entities", "list", ""},
+		{"POST", "/upstreams/upstream/targets/target/10.0.0.1:80/healthy", "targets", "set_healthy", "target"},
+		{"POST", "/upstreams/upstream/targets/target/unhealthy", "targets", "set_unhealthy", "target"},
+		{"GET", "/upstreams/upstream/health", "upstreams", "health", "upstream"},
+		{"GET", "/upstreams/upstream/targets/all", "targets", "list", ""},
+		{"GET", "/routes/route/service", "services", "get", ""},
+		{"GET", "/services/test", "services", "get", "test"},
+		{"GET", "/consumers/consumer/key-auth/key", "credentials", "get", "key"},
+		{"POST", "/admins/register", "admins", "register", ""},
+		{"GET", "/event-hooks/sources", "event-hooks", "sources", ""},
+		{"GET", "/event-hooks/hook/ping", "event-hooks", "ping", "hook"},
+		{"GET", "/status", "status", "get", ""},
+		{"GET", "/unknown/path", "", "list", ""},
+	}
+
+	for _, test := range tests {
+		info := newRequestInfo(&Config{}, test.method, test.path, http.Header{})
+
+		assert.Equal(t, test.entity, info.Entity, test.path)
+		assert.Equal(t, test.operation, info.Operation, test.path)
+		assert.Equal(t, test.entityId, info.EntityId, test.path)
+	}
+}
+
+func Test_MultiInstrumentation(t *testing.T) {
+	first := &recordingInstrumentation{}
+	second := &recordingInstrumentation{}
+	instrumentation := MultiInstrumentation(first, second)
+	info := &RequestInfo{Entity: "services", Operation: "list", Header: http.Header{}}
+
+	ctx := instrumentation.StartRequest(context.Background(), info)
+	instrumentation.EndRequest(ctx, info, 200, nil)
+
+	assert.Equal(t, []*RequestInfo{info}, first.started)
+	assert.Equal(t, []*RequestInfo{info}, second.started)
+	assert.Equal(t, []int{200}, first.ended)
+	assert.Equal(t, []int{200}, second.ended)
+	assert.Equal(t, "services.list", ctx.Value(contextKey("span")))
+}
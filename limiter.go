@@ -0,0 +1,104 @@
+package gokong
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Limiter caps the requests a client sends to kong, both in rate and in requests in flight. The limiter is shared by
+// the clients created from the same config, including the clients returned by InWorkspace and WithContext. Requests
+// wait for the limiter until the context of the client is done.
+type Limiter struct {
+	mu       sync.Mutex
+	rate     float64
+	burst    float64
+	tokens   float64
+	last     time.Time
+	inFlight chan struct{}
+}
+
+// NewLimiter creates a limiter that allows requestsPerSecond requests per second with bursts of up to burst requests
+// and at most maxInFlight requests at once. A requestsPerSecond or maxInFlight of 0 means no limit.
+func NewLimiter(requestsPerSecond float64, burst int, maxInFlight int) (*Limiter, error) {
+	if requestsPerSecond < 0 {
+		return nil, fmt.Errorf("requests per second cannot be negative, got %v", requestsPerSecond)
+	}
+	if requestsPerSecond > 0 && burst < 1 {
+		return nil, fmt.Errorf("burst must be at least 1, got %d", burst)
+	}
+	if maxInFlight < 0 {
+		return nil, fmt.Errorf("max in flight requests cannot be negative, got %d", maxInFlight)
+	}
+
+	limiter := &Limiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+	if maxInFlight > 0 {
+		limiter.inFlight = make(chan struct{}, maxInFlight)
+	}
+	return limiter, nil
+}
+
+// wait blocks until a request can be sent, the returned function must be called once the request is done.
+func (limiter *Limiter) wait(ctx context.Context) (func(), error) {
+	if limiter == nil {
+		return func() {}, nil
+	}
+
+	if err := limiter.waitRate(ctx); err != nil {
+		return nil, err
+	}
+
+	if limiter.inFlight == nil {
+		return func() {}, nil
+	}
+	select {
+	case limiter.inFlight <- struct{}{}:
+		return func() { <-limiter.inFlight }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// waitRate takes a token from the bucket, waiting for it when the bucket is empty. Tokens are reserved before
+// waiting, so waiting requests are sent in order, and given back when the context is done.
+func (limiter *Limiter) waitRate(ctx context.Context) error {
+	if limiter.rate == 0 {
+		return nil
+	}
+
+	limiter.mu.Lock()
+	now := time.Now()
+	limiter.tokens += now.Sub(limiter.last).Seconds() * limiter.rate
+	if limiter.tokens > limiter.burst {
+		limiter.tokens = limiter.burst
+	}
+	limiter.last = now
+	limiter.tokens--
+	delay := time.Duration(0)
+	if limiter.tokens < 0 {
+		delay = time.Duration(-limiter.tokens / limiter.rate * float64(time.Second))
+	}
+	limiter.mu.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		limiter.mu.Lock()
+		limiter.tokens++
+		limiter.mu.Unlock()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,130 @@
+package gokong
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newLimitedConfig(limiter *Limiter, transport roundTripperFunc) *Config {
+	return &Config{
+		HostAddress: "http://kong:8001",
+		Limiter:     limiter,
+		HTTPClient:  &http.Client{Transport: transport},
+	}
+}
+
+func notFoundResponse() *http.Response {
+	return &http.Response{StatusCode: 404, Header: http.Header{}, Body: ioutil.NopCloser(bytes.NewBufferString(`{}`))}
+}
+
+func Test_LimiterLimitsRate(t *testing.T) {
+	limiter, err := NewLimiter(20, 1, 0)
+	assert.Nil(t, err)
+	client := NewClient(newLimitedConfig(limiter, func(req *http.Request) (*http.Response, error) {
+		return notFoundResponse(), nil
+	}))
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		_, err := client.Services().GetServiceByName("service")
+		assert.Nil(t, err)
+	}
+
+	assert.True(t, time.Since(start) >= 90*time.Millisecond, "requests took %v", time.Since(start))
+}
+
+func Test_LimiterLimitsRequestsInFlight(t *testing.T) {
+	limiter, err := NewLimiter(0, 0, 2)
+	assert.Nil(t, err)
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	client := NewClient(newLimitedConfig(limiter, func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		return notFoundResponse(), nil
+	}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.InWorkspace("team-a").Services().GetServiceByName("service")
+			assert.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 2, maxInFlight)
+}
+
+func Test_LimiterStopsWaitingWhenContextIsDone(t *testing.T) {
+	limiter, err := NewLimiter(1, 1, 0)
+	assert.Nil(t, err)
+	sent := 0
+	client := NewClient(newLimitedConfig(limiter, func(req *http.Request) (*http.Response, error) {
+		sent++
+		return notFoundResponse(), nil
+	}))
+	_, err = client.Services().GetServiceByName("service")
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = client.WithContext(ctx).Services().GetServiceByName("service")
+
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "context deadline exceeded")
+	assert.Equal(t, 1, sent)
+}
+
+func Test_LimiterWaitIsNotReportedToInstrumentation(t *testing.T) {
+	limiter, err := NewLimiter(1, 1, 0)
+	assert.Nil(t, err)
+	instrumentation := &recordingInstrumentation{}
+	config := newLimitedConfig(limiter, func(req *http.Request) (*http.Response, error) {
+		return notFoundResponse(), nil
+	})
+	config.Instrumentation = instrumentation
+	client := NewClient(config)
+	_, err = client.Services().GetServiceByName("service")
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = client.WithContext(ctx).Services().GetServiceByName("service")
+
+	assert.NotNil(t, err)
+	assert.Len(t, instrumentation.started, 1)
+	assert.Equal(t, []int{404}, instrumentation.ended)
+}
+
+func Test_NewLimiterValidatesLimits(t *testing.T) {
+	_, err := NewLimiter(-1, 1, 0)
+	assert.NotNil(t, err)
+	_, err = NewLimiter(10, 0, 0)
+	assert.NotNil(t, err)
+	_, err = NewLimiter(0, 0, -1)
+	assert.NotNil(t, err)
+
+	client, err := NewClientWithOptions(WithHost("http://kong:8001"), WithRateLimit(10, 5), WithMaxInFlight(3))
+	assert.Nil(t, err)
+	assert.Equal(t, float64(10), client.config.Limiter.rate)
+	assert.Equal(t, float64(5), client.config.Limiter.burst)
+	assert.Equal(t, 3, cap(client.config.Limiter.inFlight))
+}
@@ -0,0 +1,53 @@
+package gokong
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Logger receives a debug entry for every exchange with the kong admin api, with the details of the exchange as
+// alternating keys and values. *slog.Logger implements it, loggers with a printf style method such as logrus can be
+// used through NewPrintfLogger.
+type Logger interface {
+	Debug(msg string, keysAndValues ...interface{})
+}
+
+type printfLogger struct {
+	printf func(format string, args ...interface{})
+}
+
+// NewPrintfLogger adapts a printf style function, e.g. log.Printf or logrus' Debugf, to a Logger. The keys and values
+// are appended to the message as key=value pairs.
+func NewPrintfLogger(printf func(format string, args ...interface{})) Logger {
+	return &printfLogger{printf: printf}
+}
+
+func (logger *printfLogger) Debug(msg string, keysAndValues ...interface{}) {
+	var b strings.Builder
+	b.WriteString(msg)
+	for i := 0; i < len(keysAndValues); i += 2 {
+		var value interface{} = "MISSING"
+		if i+1 < len(keysAndValues) {
+			value = keysAndValues[i+1]
+		}
+		fmt.Fprintf(&b, " %v=%q", keysAndValues[i], fmt.Sprint(value))
+	}
+	logger.printf("%s", b.String())
+}
+
+var redactedHeaders = []string{"Authorization", "Kong-Admin-Token", "Apikey"}
+
+// redactHeaders returns a copy of the headers where the credentials sent to kong are replaced.
+func redactHeaders(headers http.Header) http.Header {
+	redacted := make(http.Header, len(headers))
+	for name, values := range headers {
+		redacted[name] = values
+	}
+	for _, name := range redactedHeaders {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, "REDACTED")
+		}
+	}
+	return redacted
+}
@@ -0,0 +1,116 @@
+package gokong
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type logEntry struct {
+	msg    string
+	fields map[string]interface{}
+}
+
+type recordingLogger struct {
+	entries []logEntry
+}
+
+func (logger *recordingLogger) Debug(msg string, keysAndValues ...interface{}) {
+	fields := make(map[string]interface{})
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		fields[keysAndValues[i].(string)] = keysAndValues[i+1]
+	}
+	logger.entries = append(logger.entries, logEntry{msg: msg, fields: fields})
+}
+
+func Test_RequestLogsExchangeWithRedactedHeaders(t *testing.T) {
+	logger := &recordingLogger{}
+	config := &Config{
+		HostAddress:    "http://kong:8001",
+		Username:       "user",
+		Password:       "password",
+		ApiKey:         "key",
+		AdminToken:     "token",
+		Logger:         logger,
+		LogRequestBody: true,
+		HTTPClient: &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "token", req.Header.Get("kong-admin-token"))
+			return &http.Response{
+				StatusCode: 201,
+				Header:     http.Header{},
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"id":"123","username":"user"}`)),
+			}, nil
+		})},
+	}
+
+	_, err := NewClient(config).Consumers().Create(&ConsumerRequest{Username: "user"})
+
+	assert.Nil(t, err)
+	assert.Len(t, logger.entries, 1)
+	fields := logger.entries[0].fields
+	assert.Equal(t, "kong admin api request", logger.entries[0].msg)
+	assert.Equal(t, "POST", fields["method"])
+	assert.Equal(t, "http://kong:8001/consumers/", fields["url"])
+	assert.Equal(t, 201, fields["status"])
+	assert.Contains(t, fields["request_body"], `"username":"user"`)
+	assert.Contains(t, fields, "duration")
+	headers := fields["request_headers"].(http.Header)
+	assert.Equal(t, "REDACTED", headers.Get("Authorization"))
+	assert.Equal(t, "REDACTED", headers.Get("Kong-Admin-Token"))
+	assert.Equal(t, "REDACTED", headers.Get("Apikey"))
+}
+
+func Test_RequestDoesNotLogBodyByDefault(t *testing.T) {
+	logger := &recordingLogger{}
+	config := &Config{
+		HostAddress: "http://kong:8001",
+		Logger:      logger,
+		HTTPClient: &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 201,
+				Header:     http.Header{},
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"id":"123","name":"user"}`)),
+			}, nil
+		})},
+	}
+
+	_, err := NewClient(config).RBAC().CreateUser(&RBACUserRequest{Name: "user", UserToken: "secret-token"})
+
+	assert.Nil(t, err)
+	assert.Len(t, logger.entries, 1)
+	assert.NotContains(t, logger.entries[0].fields, "request_body")
+	assert.NotContains(t, fmt.Sprint(logger.entries[0].fields), "secret-token")
+}
+
+func Test_RequestLogsTransportErrors(t *testing.T) {
+	logger := &recordingLogger{}
+	config := &Config{
+		HostAddress: "http://kong:8001",
+		Logger:      logger,
+		HTTPClient: &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return nil, fmt.Errorf("connection refused")
+		})},
+	}
+
+	_, err := NewClient(config).Consumers().GetByUsername("user")
+
+	assert.NotNil(t, err)
+	assert.Len(t, logger.entries, 1)
+	assert.NotContains(t, logger.entries[0].fields, "status")
+	assert.Contains(t, fmt.Sprint(logger.entries[0].fields["error"]), "connection refused")
+}
+
+func Test_PrintfLogger(t *testing.T) {
+	var line string
+	logger := NewPrintfLogger(func(format string, args ...interface{}) {
+		line = fmt.Sprintf(format, args...)
+	})
+
+	logger.Debug("kong admin api request", "method", "GET", "status", 200, "dangling")
+
+	assert.Equal(t, `kong admin api request method="GET" status="200" dangling="MISSING"`, line)
+}
@@ -0,0 +1,16 @@
+// Package mocks provides programmable mocks of the gokong client interfaces.
+//
+// NewKongAdminClient returns a mock client whose entity clients are mocks too. Behaviour is programmed by setting
+// the Func field of a method, and every call is recorded so it can be asserted on afterwards:
+//
+//	client := mocks.NewKongAdminClient()
+//	client.ConsumerClient.GetByUsernameFunc = func(username string) (*gokong.Consumer, error) {
+//		return &gokong.Consumer{Id: "123", Username: username}, nil
+//	}
+//
+//	consumer, err := client.Consumers().GetByUsername("user")
+//
+//	client.ConsumerClient.AssertCalled(t, "GetByUsername", "user")
+package mocks
+
+//go:generate go run ./internal/gen .. mocks.go
@@ -0,0 +1,367 @@
+// Command gen generates the mocks in the mocks package from the client interfaces of the gokong package.
+//
+// It starts from KongAdminClient and generates a mock for it and for every interface returned by its methods.
+// Run it with go generate from the mocks directory.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const rootInterface = "KongAdminClient"
+
+type generator struct {
+	interfaces map[string]*ast.InterfaceType
+	types      map[string]bool
+	imports    map[string]string
+	used       map[string]bool
+}
+
+func main() {
+	source := ".."
+	output := "mocks.go"
+	if len(os.Args) > 1 {
+		source = os.Args[1]
+	}
+	if len(os.Args) > 2 {
+		output = os.Args[2]
+	}
+
+	g := &generator{
+		interfaces: map[string]*ast.InterfaceType{},
+		types:      map[string]bool{},
+		imports:    map[string]string{},
+		used:       map[string]bool{},
+	}
+
+	if err := g.parse(source); err != nil {
+		log.Fatalf("could not parse gokong package: %v", err)
+	}
+
+	code, err := g.generate()
+	if err != nil {
+		log.Fatalf("could not generate mocks: %v", err)
+	}
+
+	if err := ioutil.WriteFile(output, code, 0644); err != nil {
+		log.Fatalf("could not write mocks: %v", err)
+	}
+}
+
+func (g *generator) parse(dir string) error {
+	fset := token.NewFileSet()
+	packages, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return err
+	}
+
+	pkg, ok := packages["gokong"]
+	if !ok {
+		return fmt.Errorf("no gokong package found in %s", filepath.Clean(dir))
+	}
+
+	for _, file := range pkg.Files {
+		for _, spec := range file.Imports {
+			path, _ := strconv.Unquote(spec.Path.Value)
+			name := path[strings.LastIndex(path, "/")+1:]
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			g.imports[name] = path
+		}
+
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				g.types[typeSpec.Name.Name] = true
+				if iface, ok := typeSpec.Type.(*ast.InterfaceType); ok && typeSpec.Name.IsExported() {
+					g.interfaces[typeSpec.Name.Name] = iface
+				}
+			}
+		}
+	}
+
+	if _, ok := g.interfaces[rootInterface]; !ok {
+		return fmt.Errorf("no %s interface found", rootInterface)
+	}
+
+	return nil
+}
+
+// clients returns the root interface and every interface returned by one of its methods.
+func (g *generator) clients() []string {
+	seen := map[string]bool{rootInterface: true}
+	for _, method := range g.interfaces[rootInterface].Methods.List {
+		funcType, ok := method.Type.(*ast.FuncType)
+		if !ok || funcType.Results == nil {
+			continue
+		}
+		for _, result := range funcType.Results.List {
+			if ident, ok := result.Type.(*ast.Ident); ok && g.interfaces[ident.Name] != nil {
+				seen[ident.Name] = true
+			}
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (g *generator) generate() ([]byte, error) {
+	clients := g.clients()
+
+	body := &bytes.Buffer{}
+	for _, name := range clients {
+		g.writeMock(body, name, clients)
+	}
+
+	out := &bytes.Buffer{}
+	fmt.Fprintf(out, "// Code generated by mocks/internal/gen from the gokong client interfaces. DO NOT EDIT.\n\n")
+	fmt.Fprintf(out, "package mocks\n\n")
+	fmt.Fprintf(out, "import (\n")
+	paths := []string{"sync"}
+	for name := range g.used {
+		paths = append(paths, g.imports[name])
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		fmt.Fprintf(out, "\t%q\n", path)
+	}
+	fmt.Fprintf(out, "\n\t\"github.com/globocom/gokong\"\n")
+	fmt.Fprintf(out, ")\n\n")
+	out.Write(body.Bytes())
+
+	return format.Source(out.Bytes())
+}
+
+func (g *generator) writeMock(w *bytes.Buffer, name string, clients []string) {
+	iface := g.interfaces[name]
+	isRoot := name == rootInterface
+
+	fmt.Fprintf(w, "// %s is a programmable mock of gokong.%s. Each method calls the function in the\n", name, name)
+	fmt.Fprintf(w, "// matching Func field when it is set and otherwise returns zero values. All calls are recorded.\n")
+	fmt.Fprintf(w, "type %s struct {\n", name)
+	fmt.Fprintf(w, "\tRecorder\n\n")
+	if isRoot {
+		for _, client := range clients {
+			if client != rootInterface {
+				fmt.Fprintf(w, "\t%s *%s\n", client, client)
+			}
+		}
+		fmt.Fprintf(w, "\n\tclientsOnce sync.Once\n\n")
+	}
+	for _, method := range iface.Methods.List {
+		funcType := method.Type.(*ast.FuncType)
+		fmt.Fprintf(w, "\t%sFunc func%s\n", method.Names[0].Name, g.signature(funcType, true))
+	}
+	fmt.Fprintf(w, "}\n\n")
+	fmt.Fprintf(w, "var _ gokong.%s = &%s{}\n\n", name, name)
+
+	if isRoot {
+		fmt.Fprintf(w, "// New%s returns a mock client whose entity clients are mocks as well.\n", name)
+		fmt.Fprintf(w, "func New%s() *%s {\n", name, name)
+		fmt.Fprintf(w, "\treturn &%s{\n", name)
+		for _, client := range clients {
+			if client != rootInterface {
+				fmt.Fprintf(w, "\t\t%s: &%s{},\n", client, client)
+			}
+		}
+		fmt.Fprintf(w, "\t}\n}\n\n")
+
+		fmt.Fprintf(w, "// initClients creates the entity client mocks that are not set. It runs once, so that a mock that was not\n")
+		fmt.Fprintf(w, "// created with New%s can be used from several goroutines.\n", name)
+		fmt.Fprintf(w, "func (m *%s) initClients() {\n", name)
+		for _, client := range clients {
+			if client != rootInterface {
+				fmt.Fprintf(w, "\tif m.%s == nil {\n\t\tm.%s = &%s{}\n\t}\n", client, client, client)
+			}
+		}
+		fmt.Fprintf(w, "}\n\n")
+	}
+
+	for _, method := range iface.Methods.List {
+		g.writeMethod(w, name, method.Names[0].Name, method.Type.(*ast.FuncType), isRoot)
+	}
+}
+
+func (g *generator) writeMethod(w *bytes.Buffer, mock string, method string, funcType *ast.FuncType, isRoot bool) {
+	fmt.Fprintf(w, "func (m *%s) %s%s {\n", mock, method, g.signature(funcType, true))
+
+	args := g.argumentNames(funcType)
+	callArgs := make([]string, len(args))
+	copy(callArgs, args)
+	if n := len(args); n > 0 {
+		if _, variadic := funcType.Params.List[len(funcType.Params.List)-1].Type.(*ast.Ellipsis); variadic {
+			callArgs[n-1] = args[n-1] + "..."
+		}
+	}
+
+	fmt.Fprintf(w, "\tm.record(%q", method)
+	for _, arg := range args {
+		fmt.Fprintf(w, ", %s", arg)
+	}
+	fmt.Fprintf(w, ")\n")
+
+	results := g.resultTypes(funcType)
+	fmt.Fprintf(w, "\tif m.%sFunc != nil {\n", method)
+	if len(results) > 0 {
+		fmt.Fprintf(w, "\t\treturn m.%sFunc(%s)\n", method, strings.Join(callArgs, ", "))
+	} else {
+		fmt.Fprintf(w, "\t\tm.%sFunc(%s)\n\t\treturn\n", method, strings.Join(callArgs, ", "))
+	}
+	fmt.Fprintf(w, "\t}\n")
+
+	if isRoot && len(results) == 1 {
+		if ident, ok := funcType.Results.List[0].Type.(*ast.Ident); ok && g.interfaces[ident.Name] != nil {
+			if ident.Name == rootInterface {
+				fmt.Fprintf(w, "\treturn m\n}\n\n")
+				return
+			}
+			fmt.Fprintf(w, "\tm.clientsOnce.Do(m.initClients)\n")
+			fmt.Fprintf(w, "\treturn m.%s\n}\n\n", ident.Name)
+			return
+		}
+	}
+
+	names := make([]string, len(results))
+	for i, result := range results {
+		names[i] = fmt.Sprintf("r%d", i)
+		fmt.Fprintf(w, "\tvar %s %s\n", names[i], result)
+	}
+	if len(results) > 0 {
+		fmt.Fprintf(w, "\treturn %s\n", strings.Join(names, ", "))
+	}
+	fmt.Fprintf(w, "}\n\n")
+}
+
+func (g *generator) argumentNames(funcType *ast.FuncType) []string {
+	names := make([]string, 0)
+	if funcType.Params == nil {
+		return names
+	}
+	for i, field := range funcType.Params.List {
+		if len(field.Names) == 0 {
+			names = append(names, fmt.Sprintf("arg%d", i))
+			continue
+		}
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+	}
+	return names
+}
+
+func (g *generator) resultTypes(funcType *ast.FuncType) []string {
+	types := make([]string, 0)
+	if funcType.Results == nil {
+		return types
+	}
+	for _, field := range funcType.Results.List {
+		count := len(field.Names)
+		if count == 0 {
+			count = 1
+		}
+		for i := 0; i < count; i++ {
+			types = append(types, g.typeString(field.Type))
+		}
+	}
+	return types
+}
+
+func (g *generator) signature(funcType *ast.FuncType, withNames bool) string {
+	params := make([]string, 0)
+	if funcType.Params != nil {
+		names := g.argumentNames(funcType)
+		index := 0
+		for _, field := range funcType.Params.List {
+			count := len(field.Names)
+			if count == 0 {
+				count = 1
+			}
+			for i := 0; i < count; i++ {
+				if withNames {
+					params = append(params, names[index]+" "+g.typeString(field.Type))
+				} else {
+					params = append(params, g.typeString(field.Type))
+				}
+				index++
+			}
+		}
+	}
+
+	results := g.resultTypes(funcType)
+	signature := "(" + strings.Join(params, ", ") + ")"
+	switch len(results) {
+	case 0:
+		return signature
+	case 1:
+		return signature + " " + results[0]
+	}
+	return signature + " (" + strings.Join(results, ", ") + ")"
+}
+
+func (g *generator) typeString(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if g.types[t.Name] && t.IsExported() {
+			return "gokong." + t.Name
+		}
+		return t.Name
+	case *ast.StarExpr:
+		return "*" + g.typeString(t.X)
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return "[]" + g.typeString(t.Elt)
+		}
+		return "[" + g.exprString(t.Len) + "]" + g.typeString(t.Elt)
+	case *ast.MapType:
+		return "map[" + g.typeString(t.Key) + "]" + g.typeString(t.Value)
+	case *ast.Ellipsis:
+		return "..." + g.typeString(t.Elt)
+	case *ast.SelectorExpr:
+		pkg := t.X.(*ast.Ident).Name
+		g.used[pkg] = true
+		return pkg + "." + t.Sel.Name
+	case *ast.InterfaceType:
+		return "interface{}"
+	case *ast.ChanType:
+		switch t.Dir {
+		case ast.SEND:
+			return "chan<- " + g.typeString(t.Value)
+		case ast.RECV:
+			return "<-chan " + g.typeString(t.Value)
+		}
+		return "chan " + g.typeString(t.Value)
+	case *ast.FuncType:
+		return "func" + g.signature(t, false)
+	}
+	return g.exprString(expr)
+}
+
+func (g *generator) exprString(expr ast.Expr) string {
+	var buf bytes.Buffer
+	_ = format.Node(&buf, token.NewFileSet(), expr)
+	return buf.String()
+}
@@ -0,0 +1,1944 @@
+// Code generated by mocks/internal/gen from the gokong client interfaces. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/globocom/gokong"
+)
+
+// AdminsClient is a programmable mock of gokong.AdminsClient. Each method calls the function in the
+// matching Func field when it is set and otherwise returns zero values. All calls are recorded.
+type AdminsClient struct {
+	Recorder
+
+	InviteFunc              func(adminRequest *gokong.AdminRequest) (*gokong.Admin, error)
+	GetByUsernameFunc       func(username string) (*gokong.Admin, error)
+	GetByIdFunc             func(id string) (*gokong.Admin, error)
+	ListFunc                func(query *gokong.AdminQueryString) ([]*gokong.Admin, error)
+	UpdateByUsernameFunc    func(username string, adminRequest *gokong.AdminRequest) (*gokong.Admin, error)
+	UpdateByIdFunc          func(id string, adminRequest *gokong.AdminRequest) (*gokong.Admin, error)
+	DeleteByUsernameFunc    func(username string) error
+	DeleteByIdFunc          func(id string) error
+	GenerateRegisterUrlFunc func(usernameOrId string) (*gokong.AdminRegistration, error)
+	RegisterFunc            func(registerRequest *gokong.AdminRegisterRequest) error
+	GetRolesFunc            func(usernameOrId string) ([]*gokong.RBACRole, error)
+	AddRolesFunc            func(usernameOrId string, roles []string) ([]*gokong.RBACRole, error)
+	DeleteRolesFunc         func(usernameOrId string, roles []string) error
+	GetWorkspacesFunc       func(usernameOrId string) ([]*gokong.Workspace, error)
+}
+
+var _ gokong.AdminsClient = &AdminsClient{}
+
+func (m *AdminsClient) Invite(adminRequest *gokong.AdminRequest) (*gokong.Admin, error) {
+	m.record("Invite", adminRequest)
+	if m.InviteFunc != nil {
+		return m.InviteFunc(adminRequest)
+	}
+	var r0 *gokong.Admin
+	var r1 error
+	return r0, r1
+}
+
+func (m *AdminsClient) GetByUsername(username string) (*gokong.Admin, error) {
+	m.record("GetByUsername", username)
+	if m.GetByUsernameFunc != nil {
+		return m.GetByUsernameFunc(username)
+	}
+	var r0 *gokong.Admin
+	var r1 error
+	return r0, r1
+}
+
+func (m *AdminsClient) GetById(id string) (*gokong.Admin, error) {
+	m.record("GetById", id)
+	if m.GetByIdFunc != nil {
+		return m.GetByIdFunc(id)
+	}
+	var r0 *gokong.Admin
+	var r1 error
+	return r0, r1
+}
+
+func (m *AdminsClient) List(query *gokong.AdminQueryString) ([]*gokong.Admin, error) {
+	m.record("List", query)
+	if m.ListFunc != nil {
+		return m.ListFunc(query)
+	}
+	var r0 []*gokong.Admin
+	var r1 error
+	return r0, r1
+}
+
+func (m *AdminsClient) UpdateByUsername(username string, adminRequest *gokong.AdminRequest) (*gokong.Admin, error) {
+	m.record("UpdateByUsername", username, adminRequest)
+	if m.UpdateByUsernameFunc != nil {
+		return m.UpdateByUsernameFunc(username, adminRequest)
+	}
+	var r0 *gokong.Admin
+	var r1 error
+	return r0, r1
+}
+
+func (m *AdminsClient) UpdateById(id string, adminRequest *gokong.AdminRequest) (*gokong.Admin, error) {
+	m.record("UpdateById", id, adminRequest)
+	if m.UpdateByIdFunc != nil {
+		return m.UpdateByIdFunc(id, adminRequest)
+	}
+	var r0 *gokong.Admin
+	var r1 error
+	return r0, r1
+}
+
+func (m *AdminsClient) DeleteByUsername(username string) error {
+	m.record("DeleteByUsername", username)
+	if m.DeleteByUsernameFunc != nil {
+		return m.DeleteByUsernameFunc(username)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *AdminsClient) DeleteById(id string) error {
+	m.record("DeleteById", id)
+	if m.DeleteByIdFunc != nil {
+		return m.DeleteByIdFunc(id)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *AdminsClient) GenerateRegisterUrl(usernameOrId string) (*gokong.AdminRegistration, error) {
+	m.record("GenerateRegisterUrl", usernameOrId)
+	if m.GenerateRegisterUrlFunc != nil {
+		return m.GenerateRegisterUrlFunc(usernameOrId)
+	}
+	var r0 *gokong.AdminRegistration
+	var r1 error
+	return r0, r1
+}
+
+func (m *AdminsClient) Register(registerRequest *gokong.AdminRegisterRequest) error {
+	m.record("Register", registerRequest)
+	if m.RegisterFunc != nil {
+		return m.RegisterFunc(registerRequest)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *AdminsClient) GetRoles(usernameOrId string) ([]*gokong.RBACRole, error) {
+	m.record("GetRoles", usernameOrId)
+	if m.GetRolesFunc != nil {
+		return m.GetRolesFunc(usernameOrId)
+	}
+	var r0 []*gokong.RBACRole
+	var r1 error
+	return r0, r1
+}
+
+func (m *AdminsClient) AddRoles(usernameOrId string, roles []string) ([]*gokong.RBACRole, error) {
+	m.record("AddRoles", usernameOrId, roles)
+	if m.AddRolesFunc != nil {
+		return m.AddRolesFunc(usernameOrId, roles)
+	}
+	var r0 []*gokong.RBACRole
+	var r1 error
+	return r0, r1
+}
+
+func (m *AdminsClient) DeleteRoles(usernameOrId string, roles []string) error {
+	m.record("DeleteRoles", usernameOrId, roles)
+	if m.DeleteRolesFunc != nil {
+		return m.DeleteRolesFunc(usernameOrId, roles)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *AdminsClient) GetWorkspaces(usernameOrId string) ([]*gokong.Workspace, error) {
+	m.record("GetWorkspaces", usernameOrId)
+	if m.GetWorkspacesFunc != nil {
+		return m.GetWorkspacesFunc(usernameOrId)
+	}
+	var r0 []*gokong.Workspace
+	var r1 error
+	return r0, r1
+}
+
+// CertificateClient is a programmable mock of gokong.CertificateClient. Each method calls the function in the
+// matching Func field when it is set and otherwise returns zero values. All calls are recorded.
+type CertificateClient struct {
+	Recorder
+
+	GetByIdFunc    func(id string) (*gokong.Certificate, error)
+	CreateFunc     func(certificateRequest *gokong.CertificateRequest) (*gokong.Certificate, error)
+	DeleteByIdFunc func(id string) error
+	ListFunc       func() (*gokong.Certificates, error)
+	UpdateByIdFunc func(id string, certificateRequest *gokong.CertificateRequest) (*gokong.Certificate, error)
+}
+
+var _ gokong.CertificateClient = &CertificateClient{}
+
+func (m *CertificateClient) GetById(id string) (*gokong.Certificate, error) {
+	m.record("GetById", id)
+	if m.GetByIdFunc != nil {
+		return m.GetByIdFunc(id)
+	}
+	var r0 *gokong.Certificate
+	var r1 error
+	return r0, r1
+}
+
+func (m *CertificateClient) Create(certificateRequest *gokong.CertificateRequest) (*gokong.Certificate, error) {
+	m.record("Create", certificateRequest)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(certificateRequest)
+	}
+	var r0 *gokong.Certificate
+	var r1 error
+	return r0, r1
+}
+
+func (m *CertificateClient) DeleteById(id string) error {
+	m.record("DeleteById", id)
+	if m.DeleteByIdFunc != nil {
+		return m.DeleteByIdFunc(id)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *CertificateClient) List() (*gokong.Certificates, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc()
+	}
+	var r0 *gokong.Certificates
+	var r1 error
+	return r0, r1
+}
+
+func (m *CertificateClient) UpdateById(id string, certificateRequest *gokong.CertificateRequest) (*gokong.Certificate, error) {
+	m.record("UpdateById", id, certificateRequest)
+	if m.UpdateByIdFunc != nil {
+		return m.UpdateByIdFunc(id, certificateRequest)
+	}
+	var r0 *gokong.Certificate
+	var r1 error
+	return r0, r1
+}
+
+// ConsumerClient is a programmable mock of gokong.ConsumerClient. Each method calls the function in the
+// matching Func field when it is set and otherwise returns zero values. All calls are recorded.
+type ConsumerClient struct {
+	Recorder
+
+	GetByUsernameFunc      func(username string) (*gokong.Consumer, error)
+	GetByIdFunc            func(id string) (*gokong.Consumer, error)
+	CreateFunc             func(consumerRequest *gokong.ConsumerRequest) (*gokong.Consumer, error)
+	ListFunc               func(query *gokong.ConsumerQueryString) ([]*gokong.Consumer, error)
+	DeleteByUsernameFunc   func(username string) error
+	DeleteByIdFunc         func(id string) error
+	UpdateByUsernameFunc   func(username string, consumerRequest *gokong.ConsumerRequest) (*gokong.Consumer, error)
+	UpdateByIdFunc         func(id string, consumerRequest *gokong.ConsumerRequest) (*gokong.Consumer, error)
+	CreatePluginConfigFunc func(consumerId string, pluginName string, pluginConfig string) (*gokong.ConsumerPluginConfig, error)
+	GetPluginConfigFunc    func(consumerId string, pluginName string, id string) (*gokong.ConsumerPluginConfig, error)
+	GetPluginConfigsFunc   func(consumerId string, pluginName string) ([]map[string]interface{}, error)
+	DeletePluginConfigFunc func(consumerId string, pluginName string, id string) error
+	CreateManyFunc         func(consumerRequests []*gokong.ConsumerRequest, options *gokong.BulkOptions) ([]*gokong.Consumer, error)
+	UpdateManyFunc         func(ids []string, consumerRequests []*gokong.ConsumerRequest, options *gokong.BulkOptions) ([]*gokong.Consumer, error)
+	DeleteManyFunc         func(ids []string, options *gokong.BulkOptions) error
+}
+
+var _ gokong.ConsumerClient = &ConsumerClient{}
+
+func (m *ConsumerClient) GetByUsername(username string) (*gokong.Consumer, error) {
+	m.record("GetByUsername", username)
+	if m.GetByUsernameFunc != nil {
+		return m.GetByUsernameFunc(username)
+	}
+	var r0 *gokong.Consumer
+	var r1 error
+	return r0, r1
+}
+
+func (m *ConsumerClient) GetById(id string) (*gokong.Consumer, error) {
+	m.record("GetById", id)
+	if m.GetByIdFunc != nil {
+		return m.GetByIdFunc(id)
+	}
+	var r0 *gokong.Consumer
+	var r1 error
+	return r0, r1
+}
+
+func (m *ConsumerClient) Create(consumerRequest *gokong.ConsumerRequest) (*gokong.Consumer, error) {
+	m.record("Create", consumerRequest)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(consumerRequest)
+	}
+	var r0 *gokong.Consumer
+	var r1 error
+	return r0, r1
+}
+
+func (m *ConsumerClient) List(query *gokong.ConsumerQueryString) ([]*gokong.Consumer, error) {
+	m.record("List", query)
+	if m.ListFunc != nil {
+		return m.ListFunc(query)
+	}
+	var r0 []*gokong.Consumer
+	var r1 error
+	return r0, r1
+}
+
+func (m *ConsumerClient) DeleteByUsername(username string) error {
+	m.record("DeleteByUsername", username)
+	if m.DeleteByUsernameFunc != nil {
+		return m.DeleteByUsernameFunc(username)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *ConsumerClient) DeleteById(id string) error {
+	m.record("DeleteById", id)
+	if m.DeleteByIdFunc != nil {
+		return m.DeleteByIdFunc(id)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *ConsumerClient) UpdateByUsername(username string, consumerRequest *gokong.ConsumerRequest) (*gokong.Consumer, error) {
+	m.record("UpdateByUsername", username, consumerRequest)
+	if m.UpdateByUsernameFunc != nil {
+		return m.UpdateByUsernameFunc(username, consumerRequest)
+	}
+	var r0 *gokong.Consumer
+	var r1 error
+	return r0, r1
+}
+
+func (m *ConsumerClient) UpdateById(id string, consumerRequest *gokong.ConsumerRequest) (*gokong.Consumer, error) {
+	m.record("UpdateById", id, consumerRequest)
+	if m.UpdateByIdFunc != nil {
+		return m.UpdateByIdFunc(id, consumerRequest)
+	}
+	var r0 *gokong.Consumer
+	var r1 error
+	return r0, r1
+}
+
+func (m *ConsumerClient) CreatePluginConfig(consumerId string, pluginName string, pluginConfig string) (*gokong.ConsumerPluginConfig, error) {
+	m.record("CreatePluginConfig", consumerId, pluginName, pluginConfig)
+	if m.CreatePluginConfigFunc != nil {
+		return m.CreatePluginConfigFunc(consumerId, pluginName, pluginConfig)
+	}
+	var r0 *gokong.ConsumerPluginConfig
+	var r1 error
+	return r0, r1
+}
+
+func (m *ConsumerClient) GetPluginConfig(consumerId string, pluginName string, id string) (*gokong.ConsumerPluginConfig, error) {
+	m.record("GetPluginConfig", consumerId, pluginName, id)
+	if m.GetPluginConfigFunc != nil {
+		return m.GetPluginConfigFunc(consumerId, pluginName, id)
+	}
+	var r0 *gokong.ConsumerPluginConfig
+	var r1 error
+	return r0, r1
+}
+
+func (m *ConsumerClient) GetPluginConfigs(consumerId string, pluginName string) ([]map[string]interface{}, error) {
+	m.record("GetPluginConfigs", consumerId, pluginName)
+	if m.GetPluginConfigsFunc != nil {
+		return m.GetPluginConfigsFunc(consumerId, pluginName)
+	}
+	var r0 []map[string]interface{}
+	var r1 error
+	return r0, r1
+}
+
+func (m *ConsumerClient) DeletePluginConfig(consumerId string, pluginName string, id string) error {
+	m.record("DeletePluginConfig", consumerId, pluginName, id)
+	if m.DeletePluginConfigFunc != nil {
+		return m.DeletePluginConfigFunc(consumerId, pluginName, id)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *ConsumerClient) CreateMany(consumerRequests []*gokong.ConsumerRequest, options *gokong.BulkOptions) ([]*gokong.Consumer, error) {
+	m.record("CreateMany", consumerRequests, options)
+	if m.CreateManyFunc != nil {
+		return m.CreateManyFunc(consumerRequests, options)
+	}
+	var r0 []*gokong.Consumer
+	var r1 error
+	return r0, r1
+}
+
+func (m *ConsumerClient) UpdateMany(ids []string, consumerRequests []*gokong.ConsumerRequest, options *gokong.BulkOptions) ([]*gokong.Consumer, error) {
+	m.record("UpdateMany", ids, consumerRequests, options)
+	if m.UpdateManyFunc != nil {
+		return m.UpdateManyFunc(ids, consumerRequests, options)
+	}
+	var r0 []*gokong.Consumer
+	var r1 error
+	return r0, r1
+}
+
+func (m *ConsumerClient) DeleteMany(ids []string, options *gokong.BulkOptions) error {
+	m.record("DeleteMany", ids, options)
+	if m.DeleteManyFunc != nil {
+		return m.DeleteManyFunc(ids, options)
+	}
+	var r0 error
+	return r0
+}
+
+// EventHooksClient is a programmable mock of gokong.EventHooksClient. Each method calls the function in the
+// matching Func field when it is set and otherwise returns zero values. All calls are recorded.
+type EventHooksClient struct {
+	Recorder
+
+	CreateFunc      func(eventHookRequest *gokong.EventHookRequest) (*gokong.EventHook, error)
+	GetByIdFunc     func(id string) (*gokong.EventHook, error)
+	ListFunc        func(query *gokong.EventHookQueryString) ([]*gokong.EventHook, error)
+	UpdateByIdFunc  func(id string, eventHookRequest *gokong.EventHookRequest) (*gokong.EventHook, error)
+	DeleteByIdFunc  func(id string) error
+	ListSourcesFunc func() (gokong.EventHookSources, error)
+	PingFunc        func(id string) (*gokong.EventHookResult, error)
+	TestFunc        func(id string, data map[string]interface{}) (*gokong.EventHookResult, error)
+}
+
+var _ gokong.EventHooksClient = &EventHooksClient{}
+
+func (m *EventHooksClient) Create(eventHookRequest *gokong.EventHookRequest) (*gokong.EventHook, error) {
+	m.record("Create", eventHookRequest)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(eventHookRequest)
+	}
+	var r0 *gokong.EventHook
+	var r1 error
+	return r0, r1
+}
+
+func (m *EventHooksClient) GetById(id string) (*gokong.EventHook, error) {
+	m.record("GetById", id)
+	if m.GetByIdFunc != nil {
+		return m.GetByIdFunc(id)
+	}
+	var r0 *gokong.EventHook
+	var r1 error
+	return r0, r1
+}
+
+func (m *EventHooksClient) List(query *gokong.EventHookQueryString) ([]*gokong.EventHook, error) {
+	m.record("List", query)
+	if m.ListFunc != nil {
+		return m.ListFunc(query)
+	}
+	var r0 []*gokong.EventHook
+	var r1 error
+	return r0, r1
+}
+
+func (m *EventHooksClient) UpdateById(id string, eventHookRequest *gokong.EventHookRequest) (*gokong.EventHook, error) {
+	m.record("UpdateById", id, eventHookRequest)
+	if m.UpdateByIdFunc != nil {
+		return m.UpdateByIdFunc(id, eventHookRequest)
+	}
+	var r0 *gokong.EventHook
+	var r1 error
+	return r0, r1
+}
+
+func (m *EventHooksClient) DeleteById(id string) error {
+	m.record("DeleteById", id)
+	if m.DeleteByIdFunc != nil {
+		return m.DeleteByIdFunc(id)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *EventHooksClient) ListSources() (gokong.EventHookSources, error) {
+	m.record("ListSources")
+	if m.ListSourcesFunc != nil {
+		return m.ListSourcesFunc()
+	}
+	var r0 gokong.EventHookSources
+	var r1 error
+	return r0, r1
+}
+
+func (m *EventHooksClient) Ping(id string) (*gokong.EventHookResult, error) {
+	m.record("Ping", id)
+	if m.PingFunc != nil {
+		return m.PingFunc(id)
+	}
+	var r0 *gokong.EventHookResult
+	var r1 error
+	return r0, r1
+}
+
+func (m *EventHooksClient) Test(id string, data map[string]interface{}) (*gokong.EventHookResult, error) {
+	m.record("Test", id, data)
+	if m.TestFunc != nil {
+		return m.TestFunc(id, data)
+	}
+	var r0 *gokong.EventHookResult
+	var r1 error
+	return r0, r1
+}
+
+// KongAdminClient is a programmable mock of gokong.KongAdminClient. Each method calls the function in the
+// matching Func field when it is set and otherwise returns zero values. All calls are recorded.
+type KongAdminClient struct {
+	Recorder
+
+	AdminsClient      *AdminsClient
+	CertificateClient *CertificateClient
+	ConsumerClient    *ConsumerClient
+	EventHooksClient  *EventHooksClient
+	PluginClient      *PluginClient
+	RBACClient        *RBACClient
+	RouteClient       *RouteClient
+	ServiceClient     *ServiceClient
+	SnisClient        *SnisClient
+	StatusClient      *StatusClient
+	TargetClient      *TargetClient
+	UpstreamClient    *UpstreamClient
+	WorkspaceClient   *WorkspaceClient
+
+	clientsOnce sync.Once
+
+	StatusFunc       func() gokong.StatusClient
+	ConsumersFunc    func() gokong.ConsumerClient
+	PluginsFunc      func() gokong.PluginClient
+	CertificatesFunc func() gokong.CertificateClient
+	SnisFunc         func() gokong.SnisClient
+	UpstreamsFunc    func() gokong.UpstreamClient
+	RoutesFunc       func() gokong.RouteClient
+	ServicesFunc     func() gokong.ServiceClient
+	TargetsFunc      func() gokong.TargetClient
+	WorkspacesFunc   func() gokong.WorkspaceClient
+	RBACFunc         func() gokong.RBACClient
+	AdminsFunc       func() gokong.AdminsClient
+	EventHooksFunc   func() gokong.EventHooksClient
+	InWorkspaceFunc  func(workspace string) gokong.KongAdminClient
+	WithContextFunc  func(ctx context.Context) gokong.KongAdminClient
+}
+
+var _ gokong.KongAdminClient = &KongAdminClient{}
+
+// NewKongAdminClient returns a mock client whose entity clients are mocks as well.
+func NewKongAdminClient() *KongAdminClient {
+	return &KongAdminClient{
+		AdminsClient:      &AdminsClient{},
+		CertificateClient: &CertificateClient{},
+		ConsumerClient:    &ConsumerClient{},
+		EventHooksClient:  &EventHooksClient{},
+		PluginClient:      &PluginClient{},
+		RBACClient:        &RBACClient{},
+		RouteClient:       &RouteClient{},
+		ServiceClient:     &ServiceClient{},
+		SnisClient:        &SnisClient{},
+		StatusClient:      &StatusClient{},
+		TargetClient:      &TargetClient{},
+		UpstreamClient:    &UpstreamClient{},
+		WorkspaceClient:   &WorkspaceClient{},
+	}
+}
+
+// initClients creates the entity client mocks that are not set. It runs once, so that a mock that was not
+// created with NewKongAdminClient can be used from several goroutines.
+func (m *KongAdminClient) initClients() {
+	if m.AdminsClient == nil {
+		m.AdminsClient = &AdminsClient{}
+	}
+	if m.CertificateClient == nil {
+		m.CertificateClient = &CertificateClient{}
+	}
+	if m.ConsumerClient == nil {
+		m.ConsumerClient = &ConsumerClient{}
+	}
+	if m.EventHooksClient == nil {
+		m.EventHooksClient = &EventHooksClient{}
+	}
+	if m.PluginClient == nil {
+		m.PluginClient = &PluginClient{}
+	}
+	if m.RBACClient == nil {
+		m.RBACClient = &RBACClient{}
+	}
+	if m.RouteClient == nil {
+		m.RouteClient = &RouteClient{}
+	}
+	if m.ServiceClient == nil {
+		m.ServiceClient = &ServiceClient{}
+	}
+	if m.SnisClient == nil {
+		m.SnisClient = &SnisClient{}
+	}
+	if m.StatusClient == nil {
+		m.StatusClient = &StatusClient{}
+	}
+	if m.TargetClient == nil {
+		m.TargetClient = &TargetClient{}
+	}
+	if m.UpstreamClient == nil {
+		m.UpstreamClient = &UpstreamClient{}
+	}
+	if m.WorkspaceClient == nil {
+		m.WorkspaceClient = &WorkspaceClient{}
+	}
+}
+
+func (m *KongAdminClient) Status() gokong.StatusClient {
+	m.record("Status")
+	if m.StatusFunc != nil {
+		return m.StatusFunc()
+	}
+	m.clientsOnce.Do(m.initClients)
+	return m.StatusClient
+}
+
+func (m *KongAdminClient) Consumers() gokong.ConsumerClient {
+	m.record("Consumers")
+	if m.ConsumersFunc != nil {
+		return m.ConsumersFunc()
+	}
+	m.clientsOnce.Do(m.initClients)
+	return m.ConsumerClient
+}
+
+func (m *KongAdminClient) Plugins() gokong.PluginClient {
+	m.record("Plugins")
+	if m.PluginsFunc != nil {
+		return m.PluginsFunc()
+	}
+	m.clientsOnce.Do(m.initClients)
+	return m.PluginClient
+}
+
+func (m *KongAdminClient) Certificates() gokong.CertificateClient {
+	m.record("Certificates")
+	if m.CertificatesFunc != nil {
+		return m.CertificatesFunc()
+	}
+	m.clientsOnce.Do(m.initClients)
+	return m.CertificateClient
+}
+
+func (m *KongAdminClient) Snis() gokong.SnisClient {
+	m.record("Snis")
+	if m.SnisFunc != nil {
+		return m.SnisFunc()
+	}
+	m.clientsOnce.Do(m.initClients)
+	return m.SnisClient
+}
+
+func (m *KongAdminClient) Upstreams() gokong.UpstreamClient {
+	m.record("Upstreams")
+	if m.UpstreamsFunc != nil {
+		return m.UpstreamsFunc()
+	}
+	m.clientsOnce.Do(m.initClients)
+	return m.UpstreamClient
+}
+
+func (m *KongAdminClient) Routes() gokong.RouteClient {
+	m.record("Routes")
+	if m.RoutesFunc != nil {
+		return m.RoutesFunc()
+	}
+	m.clientsOnce.Do(m.initClients)
+	return m.RouteClient
+}
+
+func (m *KongAdminClient) Services() gokong.ServiceClient {
+	m.record("Services")
+	if m.ServicesFunc != nil {
+		return m.ServicesFunc()
+	}
+	m.clientsOnce.Do(m.initClients)
+	return m.ServiceClient
+}
+
+func (m *KongAdminClient) Targets() gokong.TargetClient {
+	m.record("Targets")
+	if m.TargetsFunc != nil {
+		return m.TargetsFunc()
+	}
+	m.clientsOnce.Do(m.initClients)
+	return m.TargetClient
+}
+
+func (m *KongAdminClient) Workspaces() gokong.WorkspaceClient {
+	m.record("Workspaces")
+	if m.WorkspacesFunc != nil {
+		return m.WorkspacesFunc()
+	}
+	m.clientsOnce.Do(m.initClients)
+	return m.WorkspaceClient
+}
+
+func (m *KongAdminClient) RBAC() gokong.RBACClient {
+	m.record("RBAC")
+	if m.RBACFunc != nil {
+		return m.RBACFunc()
+	}
+	m.clientsOnce.Do(m.initClients)
+	return m.RBACClient
+}
+
+func (m *KongAdminClient) Admins() gokong.AdminsClient {
+	m.record("Admins")
+	if m.AdminsFunc != nil {
+		return m.AdminsFunc()
+	}
+	m.clientsOnce.Do(m.initClients)
+	return m.AdminsClient
+}
+
+func (m *KongAdminClient) EventHooks() gokong.EventHooksClient {
+	m.record("EventHooks")
+	if m.EventHooksFunc != nil {
+		return m.EventHooksFunc()
+	}
+	m.clientsOnce.Do(m.initClients)
+	return m.EventHooksClient
+}
+
+func (m *KongAdminClient) InWorkspace(workspace string) gokong.KongAdminClient {
+	m.record("InWorkspace", workspace)
+	if m.InWorkspaceFunc != nil {
+		return m.InWorkspaceFunc(workspace)
+	}
+	return m
+}
+
+func (m *KongAdminClient) WithContext(ctx context.Context) gokong.KongAdminClient {
+	m.record("WithContext", ctx)
+	if m.WithContextFunc != nil {
+		return m.WithContextFunc(ctx)
+	}
+	return m
+}
+
+// PluginClient is a programmable mock of gokong.PluginClient. Each method calls the function in the
+// matching Func field when it is set and otherwise returns zero values. All calls are recorded.
+type PluginClient struct {
+	Recorder
+
+	GetByIdFunc         func(id string) (*gokong.Plugin, error)
+	ListFunc            func(query *gokong.PluginQueryString) ([]*gokong.Plugin, error)
+	CreateFunc          func(pluginRequest *gokong.PluginRequest) (*gokong.Plugin, error)
+	UpdateByIdFunc      func(id string, pluginRequest *gokong.PluginRequest) (*gokong.Plugin, error)
+	DeleteByIdFunc      func(id string) error
+	GetByConsumerIdFunc func(id string) (*gokong.Plugins, error)
+	GetByRouteIdFunc    func(id string) (*gokong.Plugins, error)
+	GetByServiceIdFunc  func(id string) (*gokong.Plugins, error)
+	CreateManyFunc      func(pluginRequests []*gokong.PluginRequest, options *gokong.BulkOptions) ([]*gokong.Plugin, error)
+	UpdateManyFunc      func(ids []string, pluginRequests []*gokong.PluginRequest, options *gokong.BulkOptions) ([]*gokong.Plugin, error)
+	DeleteManyFunc      func(ids []string, options *gokong.BulkOptions) error
+}
+
+var _ gokong.PluginClient = &PluginClient{}
+
+func (m *PluginClient) GetById(id string) (*gokong.Plugin, error) {
+	m.record("GetById", id)
+	if m.GetByIdFunc != nil {
+		return m.GetByIdFunc(id)
+	}
+	var r0 *gokong.Plugin
+	var r1 error
+	return r0, r1
+}
+
+func (m *PluginClient) List(query *gokong.PluginQueryString) ([]*gokong.Plugin, error) {
+	m.record("List", query)
+	if m.ListFunc != nil {
+		return m.ListFunc(query)
+	}
+	var r0 []*gokong.Plugin
+	var r1 error
+	return r0, r1
+}
+
+func (m *PluginClient) Create(pluginRequest *gokong.PluginRequest) (*gokong.Plugin, error) {
+	m.record("Create", pluginRequest)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(pluginRequest)
+	}
+	var r0 *gokong.Plugin
+	var r1 error
+	return r0, r1
+}
+
+func (m *PluginClient) UpdateById(id string, pluginRequest *gokong.PluginRequest) (*gokong.Plugin, error) {
+	m.record("UpdateById", id, pluginRequest)
+	if m.UpdateByIdFunc != nil {
+		return m.UpdateByIdFunc(id, pluginRequest)
+	}
+	var r0 *gokong.Plugin
+	var r1 error
+	return r0, r1
+}
+
+func (m *PluginClient) DeleteById(id string) error {
+	m.record("DeleteById", id)
+	if m.DeleteByIdFunc != nil {
+		return m.DeleteByIdFunc(id)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *PluginClient) GetByConsumerId(id string) (*gokong.Plugins, error) {
+	m.record("GetByConsumerId", id)
+	if m.GetByConsumerIdFunc != nil {
+		return m.GetByConsumerIdFunc(id)
+	}
+	var r0 *gokong.Plugins
+	var r1 error
+	return r0, r1
+}
+
+func (m *PluginClient) GetByRouteId(id string) (*gokong.Plugins, error) {
+	m.record("GetByRouteId", id)
+	if m.GetByRouteIdFunc != nil {
+		return m.GetByRouteIdFunc(id)
+	}
+	var r0 *gokong.Plugins
+	var r1 error
+	return r0, r1
+}
+
+func (m *PluginClient) GetByServiceId(id string) (*gokong.Plugins, error) {
+	m.record("GetByServiceId", id)
+	if m.GetByServiceIdFunc != nil {
+		return m.GetByServiceIdFunc(id)
+	}
+	var r0 *gokong.Plugins
+	var r1 error
+	return r0, r1
+}
+
+func (m *PluginClient) CreateMany(pluginRequests []*gokong.PluginRequest, options *gokong.BulkOptions) ([]*gokong.Plugin, error) {
+	m.record("CreateMany", pluginRequests, options)
+	if m.CreateManyFunc != nil {
+		return m.CreateManyFunc(pluginRequests, options)
+	}
+	var r0 []*gokong.Plugin
+	var r1 error
+	return r0, r1
+}
+
+func (m *PluginClient) UpdateMany(ids []string, pluginRequests []*gokong.PluginRequest, options *gokong.BulkOptions) ([]*gokong.Plugin, error) {
+	m.record("UpdateMany", ids, pluginRequests, options)
+	if m.UpdateManyFunc != nil {
+		return m.UpdateManyFunc(ids, pluginRequests, options)
+	}
+	var r0 []*gokong.Plugin
+	var r1 error
+	return r0, r1
+}
+
+func (m *PluginClient) DeleteMany(ids []string, options *gokong.BulkOptions) error {
+	m.record("DeleteMany", ids, options)
+	if m.DeleteManyFunc != nil {
+		return m.DeleteManyFunc(ids, options)
+	}
+	var r0 error
+	return r0
+}
+
+// RBACClient is a programmable mock of gokong.RBACClient. Each method calls the function in the
+// matching Func field when it is set and otherwise returns zero values. All calls are recorded.
+type RBACClient struct {
+	Recorder
+
+	CreateUserFunc               func(userRequest *gokong.RBACUserRequest) (*gokong.RBACUser, error)
+	GetUserFunc                  func(nameOrId string) (*gokong.RBACUser, error)
+	ListUsersFunc                func(query *gokong.RBACQueryString) ([]*gokong.RBACUser, error)
+	UpdateUserFunc               func(nameOrId string, userRequest *gokong.RBACUserRequest) (*gokong.RBACUser, error)
+	DeleteUserFunc               func(nameOrId string) error
+	GetUserRolesFunc             func(userNameOrId string) ([]*gokong.RBACRole, error)
+	AddUserRolesFunc             func(userNameOrId string, roles []string) ([]*gokong.RBACRole, error)
+	DeleteUserRolesFunc          func(userNameOrId string, roles []string) error
+	CreateRoleFunc               func(roleRequest *gokong.RBACRoleRequest) (*gokong.RBACRole, error)
+	GetRoleFunc                  func(nameOrId string) (*gokong.RBACRole, error)
+	ListRolesFunc                func(query *gokong.RBACQueryString) ([]*gokong.RBACRole, error)
+	UpdateRoleFunc               func(nameOrId string, roleRequest *gokong.RBACRoleRequest) (*gokong.RBACRole, error)
+	DeleteRoleFunc               func(nameOrId string) error
+	CreateEndpointPermissionFunc func(roleNameOrId string, permissionRequest *gokong.RBACEndpointPermissionRequest) (*gokong.RBACEndpointPermission, error)
+	GetEndpointPermissionFunc    func(roleNameOrId string, workspace string, endpoint string) (*gokong.RBACEndpointPermission, error)
+	ListEndpointPermissionsFunc  func(roleNameOrId string) ([]*gokong.RBACEndpointPermission, error)
+	UpdateEndpointPermissionFunc func(roleNameOrId string, workspace string, endpoint string, permissionRequest *gokong.RBACEndpointPermissionRequest) (*gokong.RBACEndpointPermission, error)
+	DeleteEndpointPermissionFunc func(roleNameOrId string, workspace string, endpoint string) error
+	CreateEntityPermissionFunc   func(roleNameOrId string, permissionRequest *gokong.RBACEntityPermissionRequest) (*gokong.RBACEntityPermission, error)
+	GetEntityPermissionFunc      func(roleNameOrId string, entityId string) (*gokong.RBACEntityPermission, error)
+	ListEntityPermissionsFunc    func(roleNameOrId string) ([]*gokong.RBACEntityPermission, error)
+	UpdateEntityPermissionFunc   func(roleNameOrId string, entityId string, permissionRequest *gokong.RBACEntityPermissionRequest) (*gokong.RBACEntityPermission, error)
+	DeleteEntityPermissionFunc   func(roleNameOrId string, entityId string) error
+}
+
+var _ gokong.RBACClient = &RBACClient{}
+
+func (m *RBACClient) CreateUser(userRequest *gokong.RBACUserRequest) (*gokong.RBACUser, error) {
+	m.record("CreateUser", userRequest)
+	if m.CreateUserFunc != nil {
+		return m.CreateUserFunc(userRequest)
+	}
+	var r0 *gokong.RBACUser
+	var r1 error
+	return r0, r1
+}
+
+func (m *RBACClient) GetUser(nameOrId string) (*gokong.RBACUser, error) {
+	m.record("GetUser", nameOrId)
+	if m.GetUserFunc != nil {
+		return m.GetUserFunc(nameOrId)
+	}
+	var r0 *gokong.RBACUser
+	var r1 error
+	return r0, r1
+}
+
+func (m *RBACClient) ListUsers(query *gokong.RBACQueryString) ([]*gokong.RBACUser, error) {
+	m.record("ListUsers", query)
+	if m.ListUsersFunc != nil {
+		return m.ListUsersFunc(query)
+	}
+	var r0 []*gokong.RBACUser
+	var r1 error
+	return r0, r1
+}
+
+func (m *RBACClient) UpdateUser(nameOrId string, userRequest *gokong.RBACUserRequest) (*gokong.RBACUser, error) {
+	m.record("UpdateUser", nameOrId, userRequest)
+	if m.UpdateUserFunc != nil {
+		return m.UpdateUserFunc(nameOrId, userRequest)
+	}
+	var r0 *gokong.RBACUser
+	var r1 error
+	return r0, r1
+}
+
+func (m *RBACClient) DeleteUser(nameOrId string) error {
+	m.record("DeleteUser", nameOrId)
+	if m.DeleteUserFunc != nil {
+		return m.DeleteUserFunc(nameOrId)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *RBACClient) GetUserRoles(userNameOrId string) ([]*gokong.RBACRole, error) {
+	m.record("GetUserRoles", userNameOrId)
+	if m.GetUserRolesFunc != nil {
+		return m.GetUserRolesFunc(userNameOrId)
+	}
+	var r0 []*gokong.RBACRole
+	var r1 error
+	return r0, r1
+}
+
+func (m *RBACClient) AddUserRoles(userNameOrId string, roles []string) ([]*gokong.RBACRole, error) {
+	m.record("AddUserRoles", userNameOrId, roles)
+	if m.AddUserRolesFunc != nil {
+		return m.AddUserRolesFunc(userNameOrId, roles)
+	}
+	var r0 []*gokong.RBACRole
+	var r1 error
+	return r0, r1
+}
+
+func (m *RBACClient) DeleteUserRoles(userNameOrId string, roles []string) error {
+	m.record("DeleteUserRoles", userNameOrId, roles)
+	if m.DeleteUserRolesFunc != nil {
+		return m.DeleteUserRolesFunc(userNameOrId, roles)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *RBACClient) CreateRole(roleRequest *gokong.RBACRoleRequest) (*gokong.RBACRole, error) {
+	m.record("CreateRole", roleRequest)
+	if m.CreateRoleFunc != nil {
+		return m.CreateRoleFunc(roleRequest)
+	}
+	var r0 *gokong.RBACRole
+	var r1 error
+	return r0, r1
+}
+
+func (m *RBACClient) GetRole(nameOrId string) (*gokong.RBACRole, error) {
+	m.record("GetRole", nameOrId)
+	if m.GetRoleFunc != nil {
+		return m.GetRoleFunc(nameOrId)
+	}
+	var r0 *gokong.RBACRole
+	var r1 error
+	return r0, r1
+}
+
+func (m *RBACClient) ListRoles(query *gokong.RBACQueryString) ([]*gokong.RBACRole, error) {
+	m.record("ListRoles", query)
+	if m.ListRolesFunc != nil {
+		return m.ListRolesFunc(query)
+	}
+	var r0 []*gokong.RBACRole
+	var r1 error
+	return r0, r1
+}
+
+func (m *RBACClient) UpdateRole(nameOrId string, roleRequest *gokong.RBACRoleRequest) (*gokong.RBACRole, error) {
+	m.record("UpdateRole", nameOrId, roleRequest)
+	if m.UpdateRoleFunc != nil {
+		return m.UpdateRoleFunc(nameOrId, roleRequest)
+	}
+	var r0 *gokong.RBACRole
+	var r1 error
+	return r0, r1
+}
+
+func (m *RBACClient) DeleteRole(nameOrId string) error {
+	m.record("DeleteRole", nameOrId)
+	if m.DeleteRoleFunc != nil {
+		return m.DeleteRoleFunc(nameOrId)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *RBACClient) CreateEndpointPermission(roleNameOrId string, permissionRequest *gokong.RBACEndpointPermissionRequest) (*gokong.RBACEndpointPermission, error) {
+	m.record("CreateEndpointPermission", roleNameOrId, permissionRequest)
+	if m.CreateEndpointPermissionFunc != nil {
+		return m.CreateEndpointPermissionFunc(roleNameOrId, permissionRequest)
+	}
+	var r0 *gokong.RBACEndpointPermission
+	var r1 error
+	return r0, r1
+}
+
+func (m *RBACClient) GetEndpointPermission(roleNameOrId string, workspace string, endpoint string) (*gokong.RBACEndpointPermission, error) {
+	m.record("GetEndpointPermission", roleNameOrId, workspace, endpoint)
+	if m.GetEndpointPermissionFunc != nil {
+		return m.GetEndpointPermissionFunc(roleNameOrId, workspace, endpoint)
+	}
+	var r0 *gokong.RBACEndpointPermission
+	var r1 error
+	return r0, r1
+}
+
+func (m *RBACClient) ListEndpointPermissions(roleNameOrId string) ([]*gokong.RBACEndpointPermission, error) {
+	m.record("ListEndpointPermissions", roleNameOrId)
+	if m.ListEndpointPermissionsFunc != nil {
+		return m.ListEndpointPermissionsFunc(roleNameOrId)
+	}
+	var r0 []*gokong.RBACEndpointPermission
+	var r1 error
+	return r0, r1
+}
+
+func (m *RBACClient) UpdateEndpointPermission(roleNameOrId string, workspace string, endpoint string, permissionRequest *gokong.RBACEndpointPermissionRequest) (*gokong.RBACEndpointPermission, error) {
+	m.record("UpdateEndpointPermission", roleNameOrId, workspace, endpoint, permissionRequest)
+	if m.UpdateEndpointPermissionFunc != nil {
+		return m.UpdateEndpointPermissionFunc(roleNameOrId, workspace, endpoint, permissionRequest)
+	}
+	var r0 *gokong.RBACEndpointPermission
+	var r1 error
+	return r0, r1
+}
+
+func (m *RBACClient) DeleteEndpointPermission(roleNameOrId string, workspace string, endpoint string) error {
+	m.record("DeleteEndpointPermission", roleNameOrId, workspace, endpoint)
+	if m.DeleteEndpointPermissionFunc != nil {
+		return m.DeleteEndpointPermissionFunc(roleNameOrId, workspace, endpoint)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *RBACClient) CreateEntityPermission(roleNameOrId string, permissionRequest *gokong.RBACEntityPermissionRequest) (*gokong.RBACEntityPermission, error) {
+	m.record("CreateEntityPermission", roleNameOrId, permissionRequest)
+	if m.CreateEntityPermissionFunc != nil {
+		return m.CreateEntityPermissionFunc(roleNameOrId, permissionRequest)
+	}
+	var r0 *gokong.RBACEntityPermission
+	var r1 error
+	return r0, r1
+}
+
+func (m *RBACClient) GetEntityPermission(roleNameOrId string, entityId string) (*gokong.RBACEntityPermission, error) {
+	m.record("GetEntityPermission", roleNameOrId, entityId)
+	if m.GetEntityPermissionFunc != nil {
+		return m.GetEntityPermissionFunc(roleNameOrId, entityId)
+	}
+	var r0 *gokong.RBACEntityPermission
+	var r1 error
+	return r0, r1
+}
+
+func (m *RBACClient) ListEntityPermissions(roleNameOrId string) ([]*gokong.RBACEntityPermission, error) {
+	m.record("ListEntityPermissions", roleNameOrId)
+	if m.ListEntityPermissionsFunc != nil {
+		return m.ListEntityPermissionsFunc(roleNameOrId)
+	}
+	var r0 []*gokong.RBACEntityPermission
+	var r1 error
+	return r0, r1
+}
+
+func (m *RBACClient) UpdateEntityPermission(roleNameOrId string, entityId string, permissionRequest *gokong.RBACEntityPermissionRequest) (*gokong.RBACEntityPermission, error) {
+	m.record("UpdateEntityPermission", roleNameOrId, entityId, permissionRequest)
+	if m.UpdateEntityPermissionFunc != nil {
+		return m.UpdateEntityPermissionFunc(roleNameOrId, entityId, permissionRequest)
+	}
+	var r0 *gokong.RBACEntityPermission
+	var r1 error
+	return r0, r1
+}
+
+func (m *RBACClient) DeleteEntityPermission(roleNameOrId string, entityId string) error {
+	m.record("DeleteEntityPermission", roleNameOrId, entityId)
+	if m.DeleteEntityPermissionFunc != nil {
+		return m.DeleteEntityPermissionFunc(roleNameOrId, entityId)
+	}
+	var r0 error
+	return r0
+}
+
+// RouteClient is a programmable mock of gokong.RouteClient. Each method calls the function in the
+// matching Func field when it is set and otherwise returns zero values. All calls are recorded.
+type RouteClient struct {
+	Recorder
+
+	GetByNameFunc                func(name string) (*gokong.Route, error)
+	GetByIdFunc                  func(id string) (*gokong.Route, error)
+	CreateFunc                   func(routeRequest *gokong.RouteRequest) (*gokong.Route, error)
+	ListFunc                     func(query *gokong.RouteQueryString) ([]*gokong.Route, error)
+	GetRoutesFromServiceNameFunc func(name string) ([]*gokong.Route, error)
+	GetRoutesFromServiceIdFunc   func(id string) ([]*gokong.Route, error)
+	UpdateByNameFunc             func(name string, routeRequest *gokong.RouteRequest) (*gokong.Route, error)
+	UpdateByIdFunc               func(id string, routeRequest *gokong.RouteRequest) (*gokong.Route, error)
+	DeleteByNameFunc             func(name string) error
+	DeleteByIdFunc               func(id string) error
+}
+
+var _ gokong.RouteClient = &RouteClient{}
+
+func (m *RouteClient) GetByName(name string) (*gokong.Route, error) {
+	m.record("GetByName", name)
+	if m.GetByNameFunc != nil {
+		return m.GetByNameFunc(name)
+	}
+	var r0 *gokong.Route
+	var r1 error
+	return r0, r1
+}
+
+func (m *RouteClient) GetById(id string) (*gokong.Route, error) {
+	m.record("GetById", id)
+	if m.GetByIdFunc != nil {
+		return m.GetByIdFunc(id)
+	}
+	var r0 *gokong.Route
+	var r1 error
+	return r0, r1
+}
+
+func (m *RouteClient) Create(routeRequest *gokong.RouteRequest) (*gokong.Route, error) {
+	m.record("Create", routeRequest)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(routeRequest)
+	}
+	var r0 *gokong.Route
+	var r1 error
+	return r0, r1
+}
+
+func (m *RouteClient) List(query *gokong.RouteQueryString) ([]*gokong.Route, error) {
+	m.record("List", query)
+	if m.ListFunc != nil {
+		return m.ListFunc(query)
+	}
+	var r0 []*gokong.Route
+	var r1 error
+	return r0, r1
+}
+
+func (m *RouteClient) GetRoutesFromServiceName(name string) ([]*gokong.Route, error) {
+	m.record("GetRoutesFromServiceName", name)
+	if m.GetRoutesFromServiceNameFunc != nil {
+		return m.GetRoutesFromServiceNameFunc(name)
+	}
+	var r0 []*gokong.Route
+	var r1 error
+	return r0, r1
+}
+
+func (m *RouteClient) GetRoutesFromServiceId(id string) ([]*gokong.Route, error) {
+	m.record("GetRoutesFromServiceId", id)
+	if m.GetRoutesFromServiceIdFunc != nil {
+		return m.GetRoutesFromServiceIdFunc(id)
+	}
+	var r0 []*gokong.Route
+	var r1 error
+	return r0, r1
+}
+
+func (m *RouteClient) UpdateByName(name string, routeRequest *gokong.RouteRequest) (*gokong.Route, error) {
+	m.record("UpdateByName", name, routeRequest)
+	if m.UpdateByNameFunc != nil {
+		return m.UpdateByNameFunc(name, routeRequest)
+	}
+	var r0 *gokong.Route
+	var r1 error
+	return r0, r1
+}
+
+func (m *RouteClient) UpdateById(id string, routeRequest *gokong.RouteRequest) (*gokong.Route, error) {
+	m.record("UpdateById", id, routeRequest)
+	if m.UpdateByIdFunc != nil {
+		return m.UpdateByIdFunc(id, routeRequest)
+	}
+	var r0 *gokong.Route
+	var r1 error
+	return r0, r1
+}
+
+func (m *RouteClient) DeleteByName(name string) error {
+	m.record("DeleteByName", name)
+	if m.DeleteByNameFunc != nil {
+		return m.DeleteByNameFunc(name)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *RouteClient) DeleteById(id string) error {
+	m.record("DeleteById", id)
+	if m.DeleteByIdFunc != nil {
+		return m.DeleteByIdFunc(id)
+	}
+	var r0 error
+	return r0
+}
+
+// ServiceClient is a programmable mock of gokong.ServiceClient. Each method calls the function in the
+// matching Func field when it is set and otherwise returns zero values. All calls are recorded.
+type ServiceClient struct {
+	Recorder
+
+	CreateFunc                 func(serviceRequest *gokong.ServiceRequest) (*gokong.Service, error)
+	GetServiceByNameFunc       func(name string) (*gokong.Service, error)
+	GetServiceByIdFunc         func(id string) (*gokong.Service, error)
+	GetServiceFromRouteIdFunc  func(id string) (*gokong.Service, error)
+	GetServicesFunc            func(query *gokong.ServiceQueryString) ([]*gokong.Service, error)
+	UpdateServiceByNameFunc    func(name string, serviceRequest *gokong.ServiceRequest) (*gokong.Service, error)
+	UpdateServiceByIdFunc      func(id string, serviceRequest *gokong.ServiceRequest) (*gokong.Service, error)
+	UpdateServicebyRouteIdFunc func(id string, serviceRequest *gokong.ServiceRequest) (*gokong.Service, error)
+	DeleteServiceByNameFunc    func(name string) error
+	DeleteServiceByIdFunc      func(id string) error
+}
+
+var _ gokong.ServiceClient = &ServiceClient{}
+
+func (m *ServiceClient) Create(serviceRequest *gokong.ServiceRequest) (*gokong.Service, error) {
+	m.record("Create", serviceRequest)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(serviceRequest)
+	}
+	var r0 *gokong.Service
+	var r1 error
+	return r0, r1
+}
+
+func (m *ServiceClient) GetServiceByName(name string) (*gokong.Service, error) {
+	m.record("GetServiceByName", name)
+	if m.GetServiceByNameFunc != nil {
+		return m.GetServiceByNameFunc(name)
+	}
+	var r0 *gokong.Service
+	var r1 error
+	return r0, r1
+}
+
+func (m *ServiceClient) GetServiceById(id string) (*gokong.Service, error) {
+	m.record("GetServiceById", id)
+	if m.GetServiceByIdFunc != nil {
+		return m.GetServiceByIdFunc(id)
+	}
+	var r0 *gokong.Service
+	var r1 error
+	return r0, r1
+}
+
+func (m *ServiceClient) GetServiceFromRouteId(id string) (*gokong.Service, error) {
+	m.record("GetServiceFromRouteId", id)
+	if m.GetServiceFromRouteIdFunc != nil {
+		return m.GetServiceFromRouteIdFunc(id)
+	}
+	var r0 *gokong.Service
+	var r1 error
+	return r0, r1
+}
+
+func (m *ServiceClient) GetServices(query *gokong.ServiceQueryString) ([]*gokong.Service, error) {
+	m.record("GetServices", query)
+	if m.GetServicesFunc != nil {
+		return m.GetServicesFunc(query)
+	}
+	var r0 []*gokong.Service
+	var r1 error
+	return r0, r1
+}
+
+func (m *ServiceClient) UpdateServiceByName(name string, serviceRequest *gokong.ServiceRequest) (*gokong.Service, error) {
+	m.record("UpdateServiceByName", name, serviceRequest)
+	if m.UpdateServiceByNameFunc != nil {
+		return m.UpdateServiceByNameFunc(name, serviceRequest)
+	}
+	var r0 *gokong.Service
+	var r1 error
+	return r0, r1
+}
+
+func (m *ServiceClient) UpdateServiceById(id string, serviceRequest *gokong.ServiceRequest) (*gokong.Service, error) {
+	m.record("UpdateServiceById", id, serviceRequest)
+	if m.UpdateServiceByIdFunc != nil {
+		return m.UpdateServiceByIdFunc(id, serviceRequest)
+	}
+	var r0 *gokong.Service
+	var r1 error
+	return r0, r1
+}
+
+func (m *ServiceClient) UpdateServicebyRouteId(id string, serviceRequest *gokong.ServiceRequest) (*gokong.Service, error) {
+	m.record("UpdateServicebyRouteId", id, serviceRequest)
+	if m.UpdateServicebyRouteIdFunc != nil {
+		return m.UpdateServicebyRouteIdFunc(id, serviceRequest)
+	}
+	var r0 *gokong.Service
+	var r1 error
+	return r0, r1
+}
+
+func (m *ServiceClient) DeleteServiceByName(name string) error {
+	m.record("DeleteServiceByName", name)
+	if m.DeleteServiceByNameFunc != nil {
+		return m.DeleteServiceByNameFunc(name)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *ServiceClient) DeleteServiceById(id string) error {
+	m.record("DeleteServiceById", id)
+	if m.DeleteServiceByIdFunc != nil {
+		return m.DeleteServiceByIdFunc(id)
+	}
+	var r0 error
+	return r0
+}
+
+// SnisClient is a programmable mock of gokong.SnisClient. Each method calls the function in the
+// matching Func field when it is set and otherwise returns zero values. All calls are recorded.
+type SnisClient struct {
+	Recorder
+
+	CreateFunc       func(snisRequest *gokong.SnisRequest) (*gokong.Sni, error)
+	GetByNameFunc    func(name string) (*gokong.Sni, error)
+	ListFunc         func() (*gokong.Snis, error)
+	DeleteByNameFunc func(name string) error
+	UpdateByNameFunc func(name string, snisRequest *gokong.SnisRequest) (*gokong.Sni, error)
+}
+
+var _ gokong.SnisClient = &SnisClient{}
+
+func (m *SnisClient) Create(snisRequest *gokong.SnisRequest) (*gokong.Sni, error) {
+	m.record("Create", snisRequest)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(snisRequest)
+	}
+	var r0 *gokong.Sni
+	var r1 error
+	return r0, r1
+}
+
+func (m *SnisClient) GetByName(name string) (*gokong.Sni, error) {
+	m.record("GetByName", name)
+	if m.GetByNameFunc != nil {
+		return m.GetByNameFunc(name)
+	}
+	var r0 *gokong.Sni
+	var r1 error
+	return r0, r1
+}
+
+func (m *SnisClient) List() (*gokong.Snis, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc()
+	}
+	var r0 *gokong.Snis
+	var r1 error
+	return r0, r1
+}
+
+func (m *SnisClient) DeleteByName(name string) error {
+	m.record("DeleteByName", name)
+	if m.DeleteByNameFunc != nil {
+		return m.DeleteByNameFunc(name)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *SnisClient) UpdateByName(name string, snisRequest *gokong.SnisRequest) (*gokong.Sni, error) {
+	m.record("UpdateByName", name, snisRequest)
+	if m.UpdateByNameFunc != nil {
+		return m.UpdateByNameFunc(name, snisRequest)
+	}
+	var r0 *gokong.Sni
+	var r1 error
+	return r0, r1
+}
+
+// StatusClient is a programmable mock of gokong.StatusClient. Each method calls the function in the
+// matching Func field when it is set and otherwise returns zero values. All calls are recorded.
+type StatusClient struct {
+	Recorder
+
+	GetFunc func() (*gokong.Status, error)
+}
+
+var _ gokong.StatusClient = &StatusClient{}
+
+func (m *StatusClient) Get() (*gokong.Status, error) {
+	m.record("Get")
+	if m.GetFunc != nil {
+		return m.GetFunc()
+	}
+	var r0 *gokong.Status
+	var r1 error
+	return r0, r1
+}
+
+// TargetClient is a programmable mock of gokong.TargetClient. Each method calls the function in the
+// matching Func field when it is set and otherwise returns zero values. All calls are recorded.
+type TargetClient struct {
+	Recorder
+
+	CreateFromUpstreamNameFunc                     func(name string, targetRequest *gokong.TargetRequest) (*gokong.Target, error)
+	CreateFromUpstreamIdFunc                       func(id string, targetRequest *gokong.TargetRequest) (*gokong.Target, error)
+	GetTargetsFromUpstreamNameFunc                 func(name string) ([]*gokong.Target, error)
+	GetTargetsFromUpstreamIdFunc                   func(id string) ([]*gokong.Target, error)
+	DeleteFromUpstreamByHostPortFunc               func(upstreamNameOrId string, hostPort string) error
+	DeleteFromUpstreamByIdFunc                     func(upstreamNameOrId string, id string) error
+	SetTargetFromUpstreamByHostPortAsHealthyFunc   func(upstreamNameOrId string, hostPort string) error
+	SetTargetFromUpstreamByIdAsHealthyFunc         func(upstreamNameOrId string, id string) error
+	SetTargetFromUpstreamByHostPortAsUnhealthyFunc func(upstreamNameOrId string, hostPort string) error
+	SetTargetFromUpstreamByIdAsUnhealthyFunc       func(upstreamNameOrId string, id string) error
+	GetTargetsWithHealthFromUpstreamNameFunc       func(name string) ([]*gokong.Target, error)
+	GetTargetsWithHealthFromUpstreamIdFunc         func(id string) ([]*gokong.Target, error)
+	ListFromUpstreamFunc                           func(upstreamNameOrId string, query *gokong.TargetQueryString) ([]*gokong.Target, error)
+	GetAllTargetsFromUpstreamNameFunc              func(name string) ([]*gokong.Target, error)
+	GetAllTargetsFromUpstreamIdFunc                func(id string) ([]*gokong.Target, error)
+	GetFromUpstreamByHostPortFunc                  func(upstreamNameOrId string, hostPort string) (*gokong.Target, error)
+	GetFromUpstreamByIdFunc                        func(upstreamNameOrId string, id string) (*gokong.Target, error)
+	UpdateFromUpstreamByHostPortFunc               func(upstreamNameOrId string, hostPort string, targetRequest *gokong.TargetRequest) (*gokong.Target, error)
+	UpdateFromUpstreamByIdFunc                     func(upstreamNameOrId string, id string, targetRequest *gokong.TargetRequest) (*gokong.Target, error)
+	CreateManyFromUpstreamFunc                     func(upstreamNameOrId string, targetRequests []*gokong.TargetRequest, options *gokong.BulkOptions) ([]*gokong.Target, error)
+	DeleteManyFromUpstreamFunc                     func(upstreamNameOrId string, hostPortsOrIds []string, options *gokong.BulkOptions) error
+}
+
+var _ gokong.TargetClient = &TargetClient{}
+
+func (m *TargetClient) CreateFromUpstreamName(name string, targetRequest *gokong.TargetRequest) (*gokong.Target, error) {
+	m.record("CreateFromUpstreamName", name, targetRequest)
+	if m.CreateFromUpstreamNameFunc != nil {
+		return m.CreateFromUpstreamNameFunc(name, targetRequest)
+	}
+	var r0 *gokong.Target
+	var r1 error
+	return r0, r1
+}
+
+func (m *TargetClient) CreateFromUpstreamId(id string, targetRequest *gokong.TargetRequest) (*gokong.Target, error) {
+	m.record("CreateFromUpstreamId", id, targetRequest)
+	if m.CreateFromUpstreamIdFunc != nil {
+		return m.CreateFromUpstreamIdFunc(id, targetRequest)
+	}
+	var r0 *gokong.Target
+	var r1 error
+	return r0, r1
+}
+
+func (m *TargetClient) GetTargetsFromUpstreamName(name string) ([]*gokong.Target, error) {
+	m.record("GetTargetsFromUpstreamName", name)
+	if m.GetTargetsFromUpstreamNameFunc != nil {
+		return m.GetTargetsFromUpstreamNameFunc(name)
+	}
+	var r0 []*gokong.Target
+	var r1 error
+	return r0, r1
+}
+
+func (m *TargetClient) GetTargetsFromUpstreamId(id string) ([]*gokong.Target, error) {
+	m.record("GetTargetsFromUpstreamId", id)
+	if m.GetTargetsFromUpstreamIdFunc != nil {
+		return m.GetTargetsFromUpstreamIdFunc(id)
+	}
+	var r0 []*gokong.Target
+	var r1 error
+	return r0, r1
+}
+
+func (m *TargetClient) DeleteFromUpstreamByHostPort(upstreamNameOrId string, hostPort string) error {
+	m.record("DeleteFromUpstreamByHostPort", upstreamNameOrId, hostPort)
+	if m.DeleteFromUpstreamByHostPortFunc != nil {
+		return m.DeleteFromUpstreamByHostPortFunc(upstreamNameOrId, hostPort)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *TargetClient) DeleteFromUpstreamById(upstreamNameOrId string, id string) error {
+	m.record("DeleteFromUpstreamById", upstreamNameOrId, id)
+	if m.DeleteFromUpstreamByIdFunc != nil {
+		return m.DeleteFromUpstreamByIdFunc(upstreamNameOrId, id)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *TargetClient) SetTargetFromUpstreamByHostPortAsHealthy(upstreamNameOrId string, hostPort string) error {
+	m.record("SetTargetFromUpstreamByHostPortAsHealthy", upstreamNameOrId, hostPort)
+	if m.SetTargetFromUpstreamByHostPortAsHealthyFunc != nil {
+		return m.SetTargetFromUpstreamByHostPortAsHealthyFunc(upstreamNameOrId, hostPort)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *TargetClient) SetTargetFromUpstreamByIdAsHealthy(upstreamNameOrId string, id string) error {
+	m.record("SetTargetFromUpstreamByIdAsHealthy", upstreamNameOrId, id)
+	if m.SetTargetFromUpstreamByIdAsHealthyFunc != nil {
+		return m.SetTargetFromUpstreamByIdAsHealthyFunc(upstreamNameOrId, id)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *TargetClient) SetTargetFromUpstreamByHostPortAsUnhealthy(upstreamNameOrId string, hostPort string) error {
+	m.record("SetTargetFromUpstreamByHostPortAsUnhealthy", upstreamNameOrId, hostPort)
+	if m.SetTargetFromUpstreamByHostPortAsUnhealthyFunc != nil {
+		return m.SetTargetFromUpstreamByHostPortAsUnhealthyFunc(upstreamNameOrId, hostPort)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *TargetClient) SetTargetFromUpstreamByIdAsUnhealthy(upstreamNameOrId string, id string) error {
+	m.record("SetTargetFromUpstreamByIdAsUnhealthy", upstreamNameOrId, id)
+	if m.SetTargetFromUpstreamByIdAsUnhealthyFunc != nil {
+		return m.SetTargetFromUpstreamByIdAsUnhealthyFunc(upstreamNameOrId, id)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *TargetClient) GetTargetsWithHealthFromUpstreamName(name string) ([]*gokong.Target, error) {
+	m.record("GetTargetsWithHealthFromUpstreamName", name)
+	if m.GetTargetsWithHealthFromUpstreamNameFunc != nil {
+		return m.GetTargetsWithHealthFromUpstreamNameFunc(name)
+	}
+	var r0 []*gokong.Target
+	var r1 error
+	return r0, r1
+}
+
+func (m *TargetClient) GetTargetsWithHealthFromUpstreamId(id string) ([]*gokong.Target, error) {
+	m.record("GetTargetsWithHealthFromUpstreamId", id)
+	if m.GetTargetsWithHealthFromUpstreamIdFunc != nil {
+		return m.GetTargetsWithHealthFromUpstreamIdFunc(id)
+	}
+	var r0 []*gokong.Target
+	var r1 error
+	return r0, r1
+}
+
+func (m *TargetClient) ListFromUpstream(upstreamNameOrId string, query *gokong.TargetQueryString) ([]*gokong.Target, error) {
+	m.record("ListFromUpstream", upstreamNameOrId, query)
+	if m.ListFromUpstreamFunc != nil {
+		return m.ListFromUpstreamFunc(upstreamNameOrId, query)
+	}
+	var r0 []*gokong.Target
+	var r1 error
+	return r0, r1
+}
+
+func (m *TargetClient) GetAllTargetsFromUpstreamName(name string) ([]*gokong.Target, error) {
+	m.record("GetAllTargetsFromUpstreamName", name)
+	if m.GetAllTargetsFromUpstreamNameFunc != nil {
+		return m.GetAllTargetsFromUpstreamNameFunc(name)
+	}
+	var r0 []*gokong.Target
+	var r1 error
+	return r0, r1
+}
+
+func (m *TargetClient) GetAllTargetsFromUpstreamId(id string) ([]*gokong.Target, error) {
+	m.record("GetAllTargetsFromUpstreamId", id)
+	if m.GetAllTargetsFromUpstreamIdFunc != nil {
+		return m.GetAllTargetsFromUpstreamIdFunc(id)
+	}
+	var r0 []*gokong.Target
+	var r1 error
+	return r0, r1
+}
+
+func (m *TargetClient) GetFromUpstreamByHostPort(upstreamNameOrId string, hostPort string) (*gokong.Target, error) {
+	m.record("GetFromUpstreamByHostPort", upstreamNameOrId, hostPort)
+	if m.GetFromUpstreamByHostPortFunc != nil {
+		return m.GetFromUpstreamByHostPortFunc(upstreamNameOrId, hostPort)
+	}
+	var r0 *gokong.Target
+	var r1 error
+	return r0, r1
+}
+
+func (m *TargetClient) GetFromUpstreamById(upstreamNameOrId string, id string) (*gokong.Target, error) {
+	m.record("GetFromUpstreamById", upstreamNameOrId, id)
+	if m.GetFromUpstreamByIdFunc != nil {
+		return m.GetFromUpstreamByIdFunc(upstreamNameOrId, id)
+	}
+	var r0 *gokong.Target
+	var r1 error
+	return r0, r1
+}
+
+func (m *TargetClient) UpdateFromUpstreamByHostPort(upstreamNameOrId string, hostPort string, targetRequest *gokong.TargetRequest) (*gokong.Target, error) {
+	m.record("UpdateFromUpstreamByHostPort", upstreamNameOrId, hostPort, targetRequest)
+	if m.UpdateFromUpstreamByHostPortFunc != nil {
+		return m.UpdateFromUpstreamByHostPortFunc(upstreamNameOrId, hostPort, targetRequest)
+	}
+	var r0 *gokong.Target
+	var r1 error
+	return r0, r1
+}
+
+func (m *TargetClient) UpdateFromUpstreamById(upstreamNameOrId string, id string, targetRequest *gokong.TargetRequest) (*gokong.Target, error) {
+	m.record("UpdateFromUpstreamById", upstreamNameOrId, id, targetRequest)
+	if m.UpdateFromUpstreamByIdFunc != nil {
+		return m.UpdateFromUpstreamByIdFunc(upstreamNameOrId, id, targetRequest)
+	}
+	var r0 *gokong.Target
+	var r1 error
+	return r0, r1
+}
+
+func (m *TargetClient) CreateManyFromUpstream(upstreamNameOrId string, targetRequests []*gokong.TargetRequest, options *gokong.BulkOptions) ([]*gokong.Target, error) {
+	m.record("CreateManyFromUpstream", upstreamNameOrId, targetRequests, options)
+	if m.CreateManyFromUpstreamFunc != nil {
+		return m.CreateManyFromUpstreamFunc(upstreamNameOrId, targetRequests, options)
+	}
+	var r0 []*gokong.Target
+	var r1 error
+	return r0, r1
+}
+
+func (m *TargetClient) DeleteManyFromUpstream(upstreamNameOrId string, hostPortsOrIds []string, options *gokong.BulkOptions) error {
+	m.record("DeleteManyFromUpstream", upstreamNameOrId, hostPortsOrIds, options)
+	if m.DeleteManyFromUpstreamFunc != nil {
+		return m.DeleteManyFromUpstreamFunc(upstreamNameOrId, hostPortsOrIds, options)
+	}
+	var r0 error
+	return r0
+}
+
+// UpstreamClient is a programmable mock of gokong.UpstreamClient. Each method calls the function in the
+// matching Func field when it is set and otherwise returns zero values. All calls are recorded.
+type UpstreamClient struct {
+	Recorder
+
+	GetByNameFunc             func(name string) (*gokong.Upstream, error)
+	GetByIdFunc               func(id string) (*gokong.Upstream, error)
+	CreateFunc                func(upstreamRequest *gokong.UpstreamRequest) (*gokong.Upstream, error)
+	DeleteByNameFunc          func(name string) error
+	DeleteByIdFunc            func(id string) error
+	ListFunc                  func() (*gokong.Upstreams, error)
+	ListWithQueryFunc         func(query *gokong.UpstreamQueryString) ([]*gokong.Upstream, error)
+	UpdateByNameFunc          func(name string, upstreamRequest *gokong.UpstreamRequest) (*gokong.Upstream, error)
+	UpdateByIdFunc            func(id string, upstreamRequest *gokong.UpstreamRequest) (*gokong.Upstream, error)
+	GetHealthByNameFunc       func(name string) (*gokong.UpstreamHealth, error)
+	GetHealthByIdFunc         func(id string) (*gokong.UpstreamHealth, error)
+	SetAddressAsHealthyFunc   func(upstreamNameOrId string, target string, address string) error
+	SetAddressAsUnhealthyFunc func(upstreamNameOrId string, target string, address string) error
+}
+
+var _ gokong.UpstreamClient = &UpstreamClient{}
+
+func (m *UpstreamClient) GetByName(name string) (*gokong.Upstream, error) {
+	m.record("GetByName", name)
+	if m.GetByNameFunc != nil {
+		return m.GetByNameFunc(name)
+	}
+	var r0 *gokong.Upstream
+	var r1 error
+	return r0, r1
+}
+
+func (m *UpstreamClient) GetById(id string) (*gokong.Upstream, error) {
+	m.record("GetById", id)
+	if m.GetByIdFunc != nil {
+		return m.GetByIdFunc(id)
+	}
+	var r0 *gokong.Upstream
+	var r1 error
+	return r0, r1
+}
+
+func (m *UpstreamClient) Create(upstreamRequest *gokong.UpstreamRequest) (*gokong.Upstream, error) {
+	m.record("Create", upstreamRequest)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(upstreamRequest)
+	}
+	var r0 *gokong.Upstream
+	var r1 error
+	return r0, r1
+}
+
+func (m *UpstreamClient) DeleteByName(name string) error {
+	m.record("DeleteByName", name)
+	if m.DeleteByNameFunc != nil {
+		return m.DeleteByNameFunc(name)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *UpstreamClient) DeleteById(id string) error {
+	m.record("DeleteById", id)
+	if m.DeleteByIdFunc != nil {
+		return m.DeleteByIdFunc(id)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *UpstreamClient) List() (*gokong.Upstreams, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc()
+	}
+	var r0 *gokong.Upstreams
+	var r1 error
+	return r0, r1
+}
+
+func (m *UpstreamClient) ListWithQuery(query *gokong.UpstreamQueryString) ([]*gokong.Upstream, error) {
+	m.record("ListWithQuery", query)
+	if m.ListWithQueryFunc != nil {
+		return m.ListWithQueryFunc(query)
+	}
+	var r0 []*gokong.Upstream
+	var r1 error
+	return r0, r1
+}
+
+func (m *UpstreamClient) UpdateByName(name string, upstreamRequest *gokong.UpstreamRequest) (*gokong.Upstream, error) {
+	m.record("UpdateByName", name, upstreamRequest)
+	if m.UpdateByNameFunc != nil {
+		return m.UpdateByNameFunc(name, upstreamRequest)
+	}
+	var r0 *gokong.Upstream
+	var r1 error
+	return r0, r1
+}
+
+func (m *UpstreamClient) UpdateById(id string, upstreamRequest *gokong.UpstreamRequest) (*gokong.Upstream, error) {
+	m.record("UpdateById", id, upstreamRequest)
+	if m.UpdateByIdFunc != nil {
+		return m.UpdateByIdFunc(id, upstreamRequest)
+	}
+	var r0 *gokong.Upstream
+	var r1 error
+	return r0, r1
+}
+
+func (m *UpstreamClient) GetHealthByName(name string) (*gokong.UpstreamHealth, error) {
+	m.record("GetHealthByName", name)
+	if m.GetHealthByNameFunc != nil {
+		return m.GetHealthByNameFunc(name)
+	}
+	var r0 *gokong.UpstreamHealth
+	var r1 error
+	return r0, r1
+}
+
+func (m *UpstreamClient) GetHealthById(id string) (*gokong.UpstreamHealth, error) {
+	m.record("GetHealthById", id)
+	if m.GetHealthByIdFunc != nil {
+		return m.GetHealthByIdFunc(id)
+	}
+	var r0 *gokong.UpstreamHealth
+	var r1 error
+	return r0, r1
+}
+
+func (m *UpstreamClient) SetAddressAsHealthy(upstreamNameOrId string, target string, address string) error {
+	m.record("SetAddressAsHealthy", upstreamNameOrId, target, address)
+	if m.SetAddressAsHealthyFunc != nil {
+		return m.SetAddressAsHealthyFunc(upstreamNameOrId, target, address)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *UpstreamClient) SetAddressAsUnhealthy(upstreamNameOrId string, target string, address string) error {
+	m.record("SetAddressAsUnhealthy", upstreamNameOrId, target, address)
+	if m.SetAddressAsUnhealthyFunc != nil {
+		return m.SetAddressAsUnhealthyFunc(upstreamNameOrId, target, address)
+	}
+	var r0 error
+	return r0
+}
+
+// WorkspaceClient is a programmable mock of gokong.WorkspaceClient. Each method calls the function in the
+// matching Func field when it is set and otherwise returns zero values. All calls are recorded.
+type WorkspaceClient struct {
+	Recorder
+
+	GetByNameFunc                           func(name string) (*gokong.Workspace, error)
+	GetFunc                                 func(id string) (*gokong.Workspace, error)
+	ListFunc                                func(query *gokong.WorkspaceQueryString) ([]*gokong.Workspace, error)
+	CreateFunc                              func(workspaceRequest *gokong.WorkspaceRequest) (*gokong.Workspace, error)
+	UpdateFunc                              func(workspaceRequest *gokong.WorkspaceRequest) (*gokong.Workspace, error)
+	DeleteFunc                              func() error
+	ListEntitiesFunc                        func() ([]*gokong.WorkspaceEntity, error)
+	ListEntitiesWithQueryFunc               func(query *gokong.WorkspaceEntityQueryString) ([]*gokong.WorkspaceEntity, error)
+	GetEntityFunc                           func(entityId string) (*gokong.WorkspaceEntity, error)
+	AddEntitiesToWorkspaceFunc              func(entityIds []string) error
+	DeleteMultipleEntitiesFromWorkspaceFunc func(entityIds []string) error
+}
+
+var _ gokong.WorkspaceClient = &WorkspaceClient{}
+
+func (m *WorkspaceClient) GetByName(name string) (*gokong.Workspace, error) {
+	m.record("GetByName", name)
+	if m.GetByNameFunc != nil {
+		return m.GetByNameFunc(name)
+	}
+	var r0 *gokong.Workspace
+	var r1 error
+	return r0, r1
+}
+
+func (m *WorkspaceClient) Get(id string) (*gokong.Workspace, error) {
+	m.record("Get", id)
+	if m.GetFunc != nil {
+		return m.GetFunc(id)
+	}
+	var r0 *gokong.Workspace
+	var r1 error
+	return r0, r1
+}
+
+func (m *WorkspaceClient) List(query *gokong.WorkspaceQueryString) ([]*gokong.Workspace, error) {
+	m.record("List", query)
+	if m.ListFunc != nil {
+		return m.ListFunc(query)
+	}
+	var r0 []*gokong.Workspace
+	var r1 error
+	return r0, r1
+}
+
+func (m *WorkspaceClient) Create(workspaceRequest *gokong.WorkspaceRequest) (*gokong.Workspace, error) {
+	m.record("Create", workspaceRequest)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(workspaceRequest)
+	}
+	var r0 *gokong.Workspace
+	var r1 error
+	return r0, r1
+}
+
+func (m *WorkspaceClient) Update(workspaceRequest *gokong.WorkspaceRequest) (*gokong.Workspace, error) {
+	m.record("Update", workspaceRequest)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(workspaceRequest)
+	}
+	var r0 *gokong.Workspace
+	var r1 error
+	return r0, r1
+}
+
+func (m *WorkspaceClient) Delete() error {
+	m.record("Delete")
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc()
+	}
+	var r0 error
+	return r0
+}
+
+func (m *WorkspaceClient) ListEntities() ([]*gokong.WorkspaceEntity, error) {
+	m.record("ListEntities")
+	if m.ListEntitiesFunc != nil {
+		return m.ListEntitiesFunc()
+	}
+	var r0 []*gokong.WorkspaceEntity
+	var r1 error
+	return r0, r1
+}
+
+func (m *WorkspaceClient) ListEntitiesWithQuery(query *gokong.WorkspaceEntityQueryString) ([]*gokong.WorkspaceEntity, error) {
+	m.record("ListEntitiesWithQuery", query)
+	if m.ListEntitiesWithQueryFunc != nil {
+		return m.ListEntitiesWithQueryFunc(query)
+	}
+	var r0 []*gokong.WorkspaceEntity
+	var r1 error
+	return r0, r1
+}
+
+func (m *WorkspaceClient) GetEntity(entityId string) (*gokong.WorkspaceEntity, error) {
+	m.record("GetEntity", entityId)
+	if m.GetEntityFunc != nil {
+		return m.GetEntityFunc(entityId)
+	}
+	var r0 *gokong.WorkspaceEntity
+	var r1 error
+	return r0, r1
+}
+
+func (m *WorkspaceClient) AddEntitiesToWorkspace(entityIds []string) error {
+	m.record("AddEntitiesToWorkspace", entityIds)
+	if m.AddEntitiesToWorkspaceFunc != nil {
+		return m.AddEntitiesToWorkspaceFunc(entityIds)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *WorkspaceClient) DeleteMultipleEntitiesFromWorkspace(entityIds []string) error {
+	m.record("DeleteMultipleEntitiesFromWorkspace", entityIds)
+	if m.DeleteMultipleEntitiesFromWorkspaceFunc != nil {
+		return m.DeleteMultipleEntitiesFromWorkspaceFunc(entityIds)
+	}
+	var r0 error
+	return r0
+}
@@ -0,0 +1,110 @@
+package mocks_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/globocom/gokong"
+	"github.com/globocom/gokong/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeT struct {
+	errors []string
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, format)
+}
+
+func TestKongAdminClient_ReturnsProgrammedResults(t *testing.T) {
+	client := mocks.NewKongAdminClient()
+	client.ConsumerClient.GetByUsernameFunc = func(username string) (*gokong.Consumer, error) {
+		return &gokong.Consumer{Id: "123", Username: username}, nil
+	}
+
+	var kongClient gokong.KongAdminClient = client
+	result, err := kongClient.Consumers().GetByUsername("user")
+
+	assert.Nil(t, err)
+	assert.Equal(t, &gokong.Consumer{Id: "123", Username: "user"}, result)
+	client.AssertCalled(t, "Consumers")
+	client.ConsumerClient.AssertCalled(t, "GetByUsername", "user")
+	client.ConsumerClient.AssertNumberOfCalls(t, "GetByUsername", 1)
+}
+
+func TestKongAdminClient_ReturnsZeroValuesWhenNotProgrammed(t *testing.T) {
+	client := mocks.NewKongAdminClient()
+
+	result, err := client.Services().GetServiceByName("service")
+
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+}
+
+func TestKongAdminClient_ZeroValueCreatesEntityClients(t *testing.T) {
+	client := &mocks.KongAdminClient{}
+
+	err := client.Upstreams().DeleteByName("upstream")
+
+	assert.Nil(t, err)
+	assert.NotNil(t, client.UpstreamClient)
+	client.UpstreamClient.AssertCalled(t, "DeleteByName", "upstream")
+}
+
+func TestKongAdminClient_EntityClientFuncOverridesMock(t *testing.T) {
+	client := mocks.NewKongAdminClient()
+	replacement := &mocks.TargetClient{
+		DeleteFromUpstreamByIdFunc: func(upstreamNameOrId string, id string) error {
+			return errors.New("failed")
+		},
+	}
+	client.TargetsFunc = func() gokong.TargetClient {
+		return replacement
+	}
+
+	err := client.Targets().DeleteFromUpstreamById("upstream", "target")
+
+	assert.NotNil(t, err)
+	replacement.AssertCalled(t, "DeleteFromUpstreamById", "upstream", "target")
+	client.TargetClient.AssertNotCalled(t, "DeleteFromUpstreamById")
+}
+
+func TestRecorder_AssertionsReportFailures(t *testing.T) {
+	client := mocks.NewKongAdminClient()
+	client.PluginClient.DeleteById("plugin-1")
+	fake := &fakeT{}
+
+	assert.False(t, client.PluginClient.AssertCalled(fake, "DeleteById", "plugin-2"))
+	assert.False(t, client.PluginClient.AssertNotCalled(fake, "DeleteById"))
+	assert.False(t, client.PluginClient.AssertNumberOfCalls(fake, "DeleteById", 2))
+	assert.True(t, client.PluginClient.AssertCalled(fake, "DeleteById", "plugin-1"))
+	assert.Len(t, fake.errors, 3)
+
+	client.PluginClient.ResetCalls()
+
+	assert.Len(t, client.PluginClient.Calls(), 0)
+}
+
+func TestKongAdminClient_ZeroValueCanBeUsedConcurrently(t *testing.T) {
+	client := &mocks.KongAdminClient{}
+
+	var wg sync.WaitGroup
+	clients := make([]gokong.StatusClient, 10)
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			clients[i] = client.Status()
+		}(i)
+	}
+	wg.Wait()
+
+	for _, statusClient := range clients {
+		assert.True(t, statusClient == gokong.StatusClient(client.StatusClient))
+	}
+	client.AssertNumberOfCalls(t, "Status", 10)
+}
@@ -0,0 +1,145 @@
+package mocks
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// TestingT is the subset of testing.TB used by the assertion helpers.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// Recorder records the calls made to a mock. It is safe for concurrent use.
+type Recorder struct {
+	mutex sync.Mutex
+	calls []Call
+}
+
+func (recorder *Recorder) record(method string, args ...interface{}) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	recorder.calls = append(recorder.calls, Call{Method: method, Args: args})
+}
+
+// Calls returns every call made to the mock in the order they were made.
+func (recorder *Recorder) Calls() []Call {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	calls := make([]Call, len(recorder.calls))
+	copy(calls, recorder.calls)
+	return calls
+}
+
+// CallsTo returns the calls made to a method of the mock in the order they were made.
+func (recorder *Recorder) CallsTo(method string) []Call {
+	calls := make([]Call, 0)
+	for _, call := range recorder.Calls() {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+func (recorder *Recorder) CallCount(method string) int {
+	return len(recorder.CallsTo(method))
+}
+
+// Called reports whether a method was called, with the given arguments if any are passed.
+func (recorder *Recorder) Called(method string, args ...interface{}) bool {
+	for _, call := range recorder.CallsTo(method) {
+		if len(args) == 0 || reflect.DeepEqual(call.Args, args) {
+			return true
+		}
+	}
+	return false
+}
+
+// ResetCalls forgets all recorded calls.
+func (recorder *Recorder) ResetCalls() {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	recorder.calls = nil
+}
+
+// AssertCalled fails the test unless the method was called, with the given arguments if any are passed.
+func (recorder *Recorder) AssertCalled(t TestingT, method string, args ...interface{}) bool {
+	t.Helper()
+
+	if recorder.Called(method, args...) {
+		return true
+	}
+
+	if len(args) == 0 {
+		t.Errorf("expected %s to be called, calls made: %s", method, recorder.describeCalls())
+	} else {
+		t.Errorf("expected %s to be called with %s, calls made: %s", method, describeArgs(args), recorder.describeCalls())
+	}
+	return false
+}
+
+// AssertNotCalled fails the test if the method was called, with the given arguments if any are passed.
+func (recorder *Recorder) AssertNotCalled(t TestingT, method string, args ...interface{}) bool {
+	t.Helper()
+
+	if !recorder.Called(method, args...) {
+		return true
+	}
+
+	t.Errorf("expected %s not to be called, calls made: %s", method, recorder.describeCalls())
+	return false
+}
+
+// AssertNumberOfCalls fails the test unless the method was called exactly the expected number of times.
+func (recorder *Recorder) AssertNumberOfCalls(t TestingT, method string, expected int) bool {
+	t.Helper()
+
+	if actual := recorder.CallCount(method); actual != expected {
+		t.Errorf("expected %s to be called %d times but it was called %d times", method, expected, actual)
+		return false
+	}
+	return true
+}
+
+func (recorder *Recorder) describeCalls() string {
+	calls := recorder.Calls()
+	if len(calls) == 0 {
+		return "none"
+	}
+
+	description := ""
+	for i, call := range calls {
+		if i > 0 {
+			description += ", "
+		}
+		description += call.Method + describeArgs(call.Args)
+	}
+	return description
+}
+
+func describeArgs(args []interface{}) string {
+	description := "("
+	for i, arg := range args {
+		if i > 0 {
+			description += ", "
+		}
+		value := reflect.ValueOf(arg)
+		if value.Kind() == reflect.Ptr && !value.IsNil() {
+			description += fmt.Sprintf("&%+v", value.Elem().Interface())
+		} else {
+			description += fmt.Sprintf("%#v", arg)
+		}
+	}
+	return description + ")"
+}
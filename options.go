@@ -0,0 +1,204 @@
+package gokong
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Option configures the client created by NewClientWithOptions.
+type Option func(config *Config) error
+
+// NewClientWithOptions creates a client from the default config, which reads the KONG_ADMIN_* environment variables,
+// with the options applied over it. The resulting config is validated and normalised, e.g. trailing slashes are
+// removed from the host address.
+func NewClientWithOptions(opts ...Option) (*kongAdminClient, error) {
+	config := NewDefaultConfig()
+	for _, opt := range opts {
+		if err := opt(config); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := normaliseConfig(config); err != nil {
+		return nil, err
+	}
+
+	return NewClient(config), nil
+}
+
+// WithHost sets the address of the kong admin api, e.g. http://localhost:8001.
+func WithHost(address string) Option {
+	return func(config *Config) error {
+		config.HostAddress = address
+		return nil
+	}
+}
+
+// WithBasicAuth sets the username and password sent with basic authentication.
+func WithBasicAuth(username, password string) Option {
+	return func(config *Config) error {
+		config.Username = username
+		config.Password = password
+		return nil
+	}
+}
+
+// WithAdminToken sets the kong-admin-token header sent to kong enterprise.
+func WithAdminToken(token string) Option {
+	return func(config *Config) error {
+		config.AdminToken = token
+		return nil
+	}
+}
+
+// WithApiKey sets the apikey header, for an admin api exposed through kong with the key-auth plugin.
+func WithApiKey(apiKey string) Option {
+	return func(config *Config) error {
+		config.ApiKey = apiKey
+		return nil
+	}
+}
+
+// WithWorkspace sets the kong enterprise workspace the requests are sent to.
+func WithWorkspace(workspace string) Option {
+	return func(config *Config) error {
+		config.Workspace = workspace
+		return nil
+	}
+}
+
+// WithInsecureSkipVerify disables the verification of the tls certificate of kong when skip is true.
+func WithInsecureSkipVerify(skip bool) Option {
+	return func(config *Config) error {
+		config.InsecureSkipVerify = skip
+		return nil
+	}
+}
+
+// WithHTTPClient sets the http client used to send the requests to kong.
+func WithHTTPClient(client *http.Client) Option {
+	return func(config *Config) error {
+		if client == nil {
+			return fmt.Errorf("http client cannot be nil")
+		}
+		config.HTTPClient = client
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(config *Config) error {
+		config.UserAgent = userAgent
+		return nil
+	}
+}
+
+// WithTimeout sets the time limit of each request to kong, including reading the response body.
+func WithTimeout(timeout time.Duration) Option {
+	return func(config *Config) error {
+		if timeout < 0 {
+			return fmt.Errorf("timeout cannot be negative, got %v", timeout)
+		}
+		config.Timeout = timeout
+		return nil
+	}
+}
+
+// WithLogger sets the logger that receives a debug entry for every request sent to kong.
+func WithLogger(logger Logger) Option {
+	return func(config *Config) error {
+		config.Logger = logger
+		return nil
+	}
+}
+
+// WithLogRequestBody adds the request bodies, which are not redacted, to the log entries.
+func WithLogRequestBody(logBody bool) Option {
+	return func(config *Config) error {
+		config.LogRequestBody = logBody
+		return nil
+	}
+}
+
+// WithInstrumentation sets the instrumentation notified of every request sent to kong, e.g. to trace the requests.
+func WithInstrumentation(instrumentation Instrumentation) Option {
+	return func(config *Config) error {
+		config.Instrumentation = instrumentation
+		return nil
+	}
+}
+
+// WithRateLimit limits the requests to requestsPerSecond, with bursts of up to burst requests.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(config *Config) error {
+		maxInFlight := 0
+		if config.Limiter != nil && config.Limiter.inFlight != nil {
+			maxInFlight = cap(config.Limiter.inFlight)
+		}
+		limiter, err := NewLimiter(requestsPerSecond, burst, maxInFlight)
+		if err != nil {
+			return err
+		}
+		config.Limiter = limiter
+		return nil
+	}
+}
+
+// WithMaxInFlight limits the number of requests sent to kong at once.
+func WithMaxInFlight(maxInFlight int) Option {
+	return func(config *Config) error {
+		requestsPerSecond, burst := 0.0, 0
+		if config.Limiter != nil {
+			requestsPerSecond, burst = config.Limiter.rate, int(config.Limiter.burst)
+		}
+		limiter, err := NewLimiter(requestsPerSecond, burst, maxInFlight)
+		if err != nil {
+			return err
+		}
+		config.Limiter = limiter
+		return nil
+	}
+}
+
+// WithCache sets the cache that keeps the responses to get and list requests, see NewCache.
+func WithCache(cache *Cache) Option {
+	return func(config *Config) error {
+		if cache == nil {
+			return fmt.Errorf("cache cannot be nil")
+		}
+		config.Cache = cache
+		return nil
+	}
+}
+
+func normaliseConfig(config *Config) error {
+	config.HostAddress = strings.TrimRight(strings.TrimSpace(config.HostAddress), "/")
+	if config.HostAddress == "" {
+		return fmt.Errorf("host address cannot be empty")
+	}
+
+	address, err := url.Parse(config.HostAddress)
+	if err != nil {
+		return fmt.Errorf("invalid host address %s, error: %v", config.HostAddress, err)
+	}
+	if (address.Scheme != "http" && address.Scheme != "https") || address.Host == "" {
+		return fmt.Errorf("invalid host address %s, it must be an http or https url", config.HostAddress)
+	}
+
+	config.Workspace = strings.Trim(strings.TrimSpace(config.Workspace), "/")
+	if strings.Contains(config.Workspace, "/") {
+		return fmt.Errorf("invalid workspace %s", config.Workspace)
+	}
+
+	if config.HTTPClient != nil && config.Timeout > 0 {
+		client := *config.HTTPClient
+		client.Timeout = config.Timeout
+		config.HTTPClient = &client
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package gokong
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewClientWithOptions(t *testing.T) {
+	httpClient := &http.Client{}
+
+	result, err := NewClientWithOptions(
+		WithHost(" https://kong:8444/ "),
+		WithBasicAuth("user", "password"),
+		WithAdminToken("token"),
+		WithWorkspace("/team-a/"),
+		WithHTTPClient(httpClient),
+		WithUserAgent("my-app/1.0"),
+		WithTimeout(5*time.Second),
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "https://kong:8444", result.config.HostAddress)
+	assert.Equal(t, "user", result.config.Username)
+	assert.Equal(t, "password", result.config.Password)
+	assert.Equal(t, "token", result.config.AdminToken)
+	assert.Equal(t, "team-a", result.config.Workspace)
+	assert.Equal(t, "my-app/1.0", result.config.UserAgent)
+	assert.Equal(t, 5*time.Second, result.config.HTTPClient.Timeout)
+	assert.Equal(t, time.Duration(0), httpClient.Timeout)
+}
+
+func Test_NewClientWithOptionsValidatesConfig(t *testing.T) {
+	for _, opts := range [][]Option{
+		{WithHost("")},
+		{WithHost("localhost:8001")},
+		{WithHost("ftp://localhost")},
+		{WithHost("http://localhost:8001"), WithWorkspace("team/a")},
+		{WithHost("http://localhost:8001"), WithTimeout(-time.Second)},
+		{WithHost("http://localhost:8001"), WithHTTPClient(nil)},
+	} {
+		result, err := NewClientWithOptions(opts...)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+	}
+}
+
+func Test_RequestSendsUserAgent(t *testing.T) {
+	var sent *http.Request
+	client, err := NewClientWithOptions(
+		WithHost("http://kong:8001"),
+		WithUserAgent("my-app/1.0"),
+		WithHTTPClient(&http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			sent = req
+			return nil, assert.AnError
+		})}),
+	)
+	assert.Nil(t, err)
+
+	_, err = client.Status().Get()
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "my-app/1.0", sent.Header.Get("User-Agent"))
+}
@@ -0,0 +1,104 @@
+// Package otelgokong traces the requests gokong sends to the kong admin api with OpenTelemetry.
+//
+// Every request gets a client span named after the entity and the operation, e.g. kong.services.update, which is a
+// child of the span in the context of the client:
+//
+//	config := gokong.NewDefaultConfig()
+//	config.Instrumentation = otelgokong.New()
+//	service, err := gokong.NewClient(config).WithContext(ctx).Services().GetServiceByName("service")
+package otelgokong
+
+import (
+	"context"
+	"strings"
+
+	"github.com/globocom/gokong"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/globocom/gokong/otelgokong"
+
+type instrumentation struct {
+	tracerProvider trace.TracerProvider
+	propagators    propagation.TextMapPropagator
+	tracer         trace.Tracer
+}
+
+type Option func(instrumentation *instrumentation)
+
+// WithTracerProvider sets the provider of the tracer, the global provider is used by default.
+func WithTracerProvider(tracerProvider trace.TracerProvider) Option {
+	return func(instrumentation *instrumentation) {
+		instrumentation.tracerProvider = tracerProvider
+	}
+}
+
+// WithPropagators sets the propagators that inject the span into the headers of the requests, the global propagators
+// are used by default.
+func WithPropagators(propagators propagation.TextMapPropagator) Option {
+	return func(instrumentation *instrumentation) {
+		instrumentation.propagators = propagators
+	}
+}
+
+// New returns the instrumentation to set in gokong.Config.Instrumentation.
+func New(opts ...Option) gokong.Instrumentation {
+	instrumentation := &instrumentation{
+		tracerProvider: otel.GetTracerProvider(),
+		propagators:    otel.GetTextMapPropagator(),
+	}
+	for _, opt := range opts {
+		opt(instrumentation)
+	}
+	instrumentation.tracer = instrumentation.tracerProvider.Tracer(instrumentationName)
+	return instrumentation
+}
+
+func (instrumentation *instrumentation) StartRequest(ctx context.Context, info *gokong.RequestInfo) context.Context {
+	attributes := []attribute.KeyValue{
+		attribute.String("kong.entity", info.Entity),
+		attribute.String("kong.operation", info.Operation),
+		attribute.String("http.request.method", info.Method),
+		attribute.String("url.path", info.Path),
+		attribute.Int("http.request.resend_count", info.RetryCount),
+	}
+	if info.Workspace != "" {
+		attributes = append(attributes, attribute.String("kong.workspace", info.Workspace))
+	}
+	if info.EntityId != "" {
+		attributes = append(attributes, attribute.String("kong.entity_id", info.EntityId))
+	}
+
+	ctx, _ = instrumentation.tracer.Start(ctx, spanName(info),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributes...),
+	)
+	instrumentation.propagators.Inject(ctx, propagation.HeaderCarrier(info.Header))
+	return ctx
+}
+
+func (instrumentation *instrumentation) EndRequest(ctx context.Context, info *gokong.RequestInfo, statusCode int, err error) {
+	span := trace.SpanFromContext(ctx)
+	if statusCode != 0 {
+		span.SetAttributes(attribute.Int("http.response.status_code", statusCode))
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else if statusCode >= 400 {
+		span.SetStatus(codes.Error, "")
+	}
+	span.End()
+}
+
+func spanName(info *gokong.RequestInfo) string {
+	parts := []string{"kong"}
+	if info.Entity != "" {
+		parts = append(parts, info.Entity)
+	}
+	return strings.Join(append(parts, info.Operation), ".")
+}
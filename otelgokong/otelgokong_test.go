@@ -0,0 +1,104 @@
+package otelgokong_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/globocom/gokong"
+	"github.com/globocom/gokong/gokongtest"
+	"github.com/globocom/gokong/otelgokong"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newClient(server *gokongtest.Server, recorder *tracetest.SpanRecorder) gokong.KongAdminClient {
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	return gokong.NewClient(&gokong.Config{
+		HostAddress: server.URL,
+		Instrumentation: otelgokong.New(
+			otelgokong.WithTracerProvider(provider),
+			otelgokong.WithPropagators(propagation.TraceContext{}),
+		),
+	})
+}
+
+func attributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	values := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		values[kv.Key] = kv.Value
+	}
+	return values
+}
+
+func TestSpanPerRequest(t *testing.T) {
+	server := gokongtest.NewServer()
+	defer server.Close()
+	recorder := tracetest.NewSpanRecorder()
+	client := newClient(server, recorder)
+
+	service, err := client.Services().Create(&gokong.ServiceRequest{
+		Name: gokong.String("service"),
+		Host: gokong.String("example.com"),
+	})
+	assert.Nil(t, err)
+	_, err = client.Services().UpdateServiceById(*service.Id, &gokong.ServiceRequest{Host: gokong.String("example.org")})
+	assert.Nil(t, err)
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "kong.services.create", spans[0].Name())
+	assert.Equal(t, "kong.services.update", spans[1].Name())
+	assert.Equal(t, trace.SpanKindClient, spans[1].SpanKind())
+
+	values := attributes(spans[1])
+	assert.Equal(t, "services", values["kong.entity"].AsString())
+	assert.Equal(t, "update", values["kong.operation"].AsString())
+	assert.Equal(t, *service.Id, values["kong.entity_id"].AsString())
+	assert.Equal(t, int64(200), values["http.response.status_code"].AsInt64())
+	assert.Equal(t, int64(0), values["http.request.resend_count"].AsInt64())
+}
+
+func TestSpanNamesDoNotContainIdsOrAddresses(t *testing.T) {
+	server := gokongtest.NewServer()
+	defer server.Close()
+	recorder := tracetest.NewSpanRecorder()
+	client := newClient(server, recorder)
+
+	upstream, err := client.Upstreams().Create(&gokong.UpstreamRequest{Name: "upstream"})
+	assert.Nil(t, err)
+	target, err := client.Targets().CreateFromUpstreamId(upstream.Id, &gokong.TargetRequest{Target: "10.0.0.1:80", Weight: 100})
+	assert.Nil(t, err)
+	err = client.Upstreams().SetAddressAsHealthy(upstream.Id, *target.Id, "10.0.0.1:80")
+	assert.Nil(t, err)
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 3)
+	assert.Equal(t, "kong.targets.set_healthy", spans[2].Name())
+	assert.Equal(t, *target.Id, attributes(spans[2])["kong.entity_id"].AsString())
+}
+
+func TestSpanIsChildOfCallerSpan(t *testing.T) {
+	server := gokongtest.NewServer()
+	defer server.Close()
+	recorder := tracetest.NewSpanRecorder()
+	client := newClient(server, recorder)
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "reconcile")
+
+	_, err := client.InWorkspace("default").WithContext(ctx).Consumers().GetByUsername("missing")
+	parent.End()
+
+	assert.Nil(t, err)
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "kong.consumers.get", spans[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, parent.SpanContext().TraceID(), spans[0].SpanContext().TraceID())
+	assert.Equal(t, "default", attributes(spans[0])["kong.workspace"].AsString())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+}
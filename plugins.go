@@ -14,6 +14,9 @@ type PluginClient interface {
 	GetByConsumerId(id string) (*Plugins, error)
 	GetByRouteId(id string) (*Plugins, error)
 	GetByServiceId(id string) (*Plugins, error)
+	CreateMany(pluginRequests []*PluginRequest, options *BulkOptions) ([]*Plugin, error)
+	UpdateMany(ids []string, pluginRequests []*PluginRequest, options *BulkOptions) ([]*Plugin, error)
+	DeleteMany(ids []string, options *BulkOptions) error
 }
 
 type pluginClient struct {
@@ -241,3 +244,36 @@ func (pluginClient *pluginClient) GetByServiceId(id string) (*Plugins, error) {
 
 	return plugins, nil
 }
+
+// CreateMany creates the plugins with a bounded number of requests at once. The created plugins are returned in the
+// order of the requests, with nil for the plugins that could not be created, and the error is a *BulkError.
+func (pluginClient *pluginClient) CreateMany(pluginRequests []*PluginRequest, options *BulkOptions) ([]*Plugin, error) {
+	plugins := make([]*Plugin, len(pluginRequests))
+	err := runBulk(pluginClient.config, len(pluginRequests), options, func(i int) error {
+		var err error
+		plugins[i], err = pluginClient.Create(pluginRequests[i])
+		return err
+	})
+	return plugins, err
+}
+
+// UpdateMany updates the plugin of each id with the request at the same index, in the same way as CreateMany.
+func (pluginClient *pluginClient) UpdateMany(ids []string, pluginRequests []*PluginRequest, options *BulkOptions) ([]*Plugin, error) {
+	if err := checkBulkUpdate(len(ids), len(pluginRequests)); err != nil {
+		return nil, err
+	}
+	plugins := make([]*Plugin, len(ids))
+	err := runBulk(pluginClient.config, len(ids), options, func(i int) error {
+		var err error
+		plugins[i], err = pluginClient.UpdateById(ids[i], pluginRequests[i])
+		return err
+	})
+	return plugins, err
+}
+
+// DeleteMany deletes the plugins with a bounded number of requests at once, the error is a *BulkError.
+func (pluginClient *pluginClient) DeleteMany(ids []string, options *BulkOptions) error {
+	return runBulk(pluginClient.config, len(ids), options, func(i int) error {
+		return pluginClient.DeleteById(ids[i])
+	})
+}
@@ -0,0 +1,116 @@
+// Package promgokong measures the requests gokong sends to the kong admin api with prometheus metrics.
+//
+// The collector is both the instrumentation of the client and a prometheus.Collector:
+//
+//	collector := promgokong.NewCollector()
+//	prometheus.MustRegister(collector)
+//	config := gokong.NewDefaultConfig()
+//	config.Instrumentation = collector
+//
+// The metrics are labeled by entity, e.g. services, operation, e.g. update, http method and status class, e.g. 2xx,
+// which is empty when no response was received. All the labels have a bounded set of values, ids, names and
+// addresses of entities are never used as labels:
+//
+//	gokong_requests_total{entity, operation, method, status_class}
+//	gokong_request_errors_total{entity, operation, method, status_class}
+//	gokong_request_duration_seconds{entity, operation, method}
+package promgokong
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/globocom/gokong"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type startKey struct{}
+
+type Collector struct {
+	requests *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+type options struct {
+	namespace string
+	buckets   []float64
+}
+
+type Option func(options *options)
+
+// WithNamespace sets the namespace of the metrics, gokong by default.
+func WithNamespace(namespace string) Option {
+	return func(options *options) {
+		options.namespace = namespace
+	}
+}
+
+// WithBuckets sets the buckets of the duration histogram, prometheus.DefBuckets by default.
+func WithBuckets(buckets []float64) Option {
+	return func(options *options) {
+		options.buckets = buckets
+	}
+}
+
+func NewCollector(opts ...Option) *Collector {
+	options := &options{namespace: "gokong", buckets: prometheus.DefBuckets}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: options.namespace,
+			Name:      "requests_total",
+			Help:      "Number of requests sent to the kong admin api.",
+		}, []string{"entity", "operation", "method", "status_class"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: options.namespace,
+			Name:      "request_errors_total",
+			Help:      "Number of requests to the kong admin api that failed or got a 4xx or 5xx response.",
+		}, []string{"entity", "operation", "method", "status_class"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: options.namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Duration of the requests sent to the kong admin api.",
+			Buckets:   options.buckets,
+		}, []string{"entity", "operation", "method"}),
+	}
+}
+
+func (collector *Collector) StartRequest(ctx context.Context, info *gokong.RequestInfo) context.Context {
+	return context.WithValue(ctx, startKey{}, time.Now())
+}
+
+func (collector *Collector) EndRequest(ctx context.Context, info *gokong.RequestInfo, statusCode int, err error) {
+	class := statusClass(statusCode)
+	collector.requests.WithLabelValues(info.Entity, info.Operation, info.Method, class).Inc()
+	if err != nil || statusCode >= 400 {
+		collector.errors.WithLabelValues(info.Entity, info.Operation, info.Method, class).Inc()
+	}
+	if start, ok := ctx.Value(startKey{}).(time.Time); ok {
+		collector.duration.WithLabelValues(info.Entity, info.Operation, info.Method).Observe(time.Since(start).Seconds())
+	}
+}
+
+// statusClass returns the class of a status code, e.g. 4xx for 404, or an empty class when there was no response.
+func statusClass(statusCode int) string {
+	if statusCode < 100 || statusCode > 599 {
+		return ""
+	}
+	return strconv.Itoa(statusCode/100) + "xx"
+}
+
+func (collector *Collector) Describe(descs chan<- *prometheus.Desc) {
+	collector.requests.Describe(descs)
+	collector.errors.Describe(descs)
+	collector.duration.Describe(descs)
+}
+
+func (collector *Collector) Collect(metrics chan<- prometheus.Metric) {
+	collector.requests.Collect(metrics)
+	collector.errors.Collect(metrics)
+	collector.duration.Collect(metrics)
+}
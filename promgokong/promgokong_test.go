@@ -0,0 +1,92 @@
+package promgokong_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/globocom/gokong"
+	"github.com/globocom/gokong/gokongtest"
+	"github.com/globocom/gokong/promgokong"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectorMeasuresRequests(t *testing.T) {
+	server := gokongtest.NewServer()
+	defer server.Close()
+	collector := promgokong.NewCollector()
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collector)
+	client := gokong.NewClient(&gokong.Config{HostAddress: server.URL, Instrumentation: collector})
+
+	_, err := client.Services().Create(&gokong.ServiceRequest{
+		Name: gokong.String("service"),
+		Host: gokong.String("example.com"),
+	})
+	assert.Nil(t, err)
+	_, err = client.Services().GetServiceByName("service")
+	assert.Nil(t, err)
+	_, err = client.Services().GetServiceByName("missing")
+	assert.Nil(t, err)
+
+	expected := `
+# HELP gokong_requests_total Number of requests sent to the kong admin api.
+# TYPE gokong_requests_total counter
+gokong_requests_total{entity="services",method="GET",operation="get",status_class="2xx"} 1
+gokong_requests_total{entity="services",method="GET",operation="get",status_class="4xx"} 1
+gokong_requests_total{entity="services",method="POST",operation="create",status_class="2xx"} 1
+# HELP gokong_request_errors_total Number of requests to the kong admin api that failed or got a 4xx or 5xx response.
+# TYPE gokong_request_errors_total counter
+gokong_request_errors_total{entity="services",method="GET",operation="get",status_class="4xx"} 1
+`
+	err = testutil.GatherAndCompare(registry, strings.NewReader(expected), "gokong_requests_total", "gokong_request_errors_total")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, testutil.CollectAndCount(collector, "gokong_request_duration_seconds"))
+}
+
+func TestCollectorCountsTransportErrors(t *testing.T) {
+	collector := promgokong.NewCollector(promgokong.WithNamespace("kong_client"))
+	client := gokong.NewClient(&gokong.Config{HostAddress: "http://127.0.0.1:1", Instrumentation: collector})
+
+	_, err := client.Consumers().GetByUsername("user")
+	assert.NotNil(t, err)
+
+	expected := `
+# HELP kong_client_request_errors_total Number of requests to the kong admin api that failed or got a 4xx or 5xx response.
+# TYPE kong_client_request_errors_total counter
+kong_client_request_errors_total{entity="consumers",method="GET",operation="get",status_class=""} 1
+`
+	err = testutil.CollectAndCompare(collector, strings.NewReader(expected), "kong_client_request_errors_total")
+	assert.Nil(t, err)
+}
+
+func TestCollectorLabelsHealthRequestsWithoutIdsOrAddresses(t *testing.T) {
+	server := gokongtest.NewServer()
+	defer server.Close()
+	collector := promgokong.NewCollector()
+	client := gokong.NewClient(&gokong.Config{HostAddress: server.URL, Instrumentation: collector})
+
+	upstream, err := client.Upstreams().Create(&gokong.UpstreamRequest{Name: "upstream"})
+	assert.Nil(t, err)
+	for _, address := range []string{"10.0.0.1:80", "10.0.0.2:80", "10.0.0.3:80"} {
+		target, err := client.Targets().CreateFromUpstreamId(upstream.Id, &gokong.TargetRequest{Target: address, Weight: 100})
+		assert.Nil(t, err)
+		assert.Nil(t, client.Upstreams().SetAddressAsHealthy(upstream.Id, *target.Id, address))
+		assert.Nil(t, client.Targets().SetTargetFromUpstreamByIdAsUnhealthy(upstream.Id, *target.Id))
+	}
+	_, err = client.Upstreams().GetHealthById(upstream.Id)
+	assert.Nil(t, err)
+
+	expected := `
+# HELP gokong_requests_total Number of requests sent to the kong admin api.
+# TYPE gokong_requests_total counter
+gokong_requests_total{entity="targets",method="POST",operation="create",status_class="2xx"} 3
+gokong_requests_total{entity="targets",method="POST",operation="set_healthy",status_class="2xx"} 3
+gokong_requests_total{entity="targets",method="POST",operation="set_unhealthy",status_class="2xx"} 3
+gokong_requests_total{entity="upstreams",method="GET",operation="health",status_class="2xx"} 2
+gokong_requests_total{entity="upstreams",method="POST",operation="create",status_class="2xx"} 1
+`
+	err = testutil.CollectAndCompare(collector, strings.NewReader(expected), "gokong_requests_total")
+	assert.Nil(t, err)
+}
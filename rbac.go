@@ -0,0 +1,709 @@
+package gokong
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type RBACClient interface {
+	CreateUser(userRequest *RBACUserRequest) (*RBACUser, error)
+	GetUser(nameOrId string) (*RBACUser, error)
+	ListUsers(query *RBACQueryString) ([]*RBACUser, error)
+	UpdateUser(nameOrId string, userRequest *RBACUserRequest) (*RBACUser, error)
+	DeleteUser(nameOrId string) error
+	GetUserRoles(userNameOrId string) ([]*RBACRole, error)
+	AddUserRoles(userNameOrId string, roles []string) ([]*RBACRole, error)
+	DeleteUserRoles(userNameOrId string, roles []string) error
+	CreateRole(roleRequest *RBACRoleRequest) (*RBACRole, error)
+	GetRole(nameOrId string) (*RBACRole, error)
+	ListRoles(query *RBACQueryString) ([]*RBACRole, error)
+	UpdateRole(nameOrId string, roleRequest *RBACRoleRequest) (*RBACRole, error)
+	DeleteRole(nameOrId string) error
+	CreateEndpointPermission(roleNameOrId string, permissionRequest *RBACEndpointPermissionRequest) (*RBACEndpointPermission, error)
+	GetEndpointPermission(roleNameOrId string, workspace string, endpoint string) (*RBACEndpointPermission, error)
+	ListEndpointPermissions(roleNameOrId string) ([]*RBACEndpointPermission, error)
+	UpdateEndpointPermission(roleNameOrId string, workspace string, endpoint string, permissionRequest *RBACEndpointPermissionRequest) (*RBACEndpointPermission, error)
+	DeleteEndpointPermission(roleNameOrId string, workspace string, endpoint string) error
+	CreateEntityPermission(roleNameOrId string, permissionRequest *RBACEntityPermissionRequest) (*RBACEntityPermission, error)
+	GetEntityPermission(roleNameOrId string, entityId string) (*RBACEntityPermission, error)
+	ListEntityPermissions(roleNameOrId string) ([]*RBACEntityPermission, error)
+	UpdateEntityPermission(roleNameOrId string, entityId string, permissionRequest *RBACEntityPermissionRequest) (*RBACEntityPermission, error)
+	DeleteEntityPermission(roleNameOrId string, entityId string) error
+}
+
+type rbacClient struct {
+	config *Config
+}
+
+type RBACUserRequest struct {
+	Name      string `json:"name,omitempty" yaml:"name,omitempty"`
+	UserToken string `json:"user_token,omitempty" yaml:"user_token,omitempty"`
+	Enabled   *bool  `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	Comment   string `json:"comment,omitempty" yaml:"comment,omitempty"`
+}
+
+type RBACUser struct {
+	Id             string `json:"id,omitempty" yaml:"id,omitempty"`
+	Name           string `json:"name,omitempty" yaml:"name,omitempty"`
+	UserToken      string `json:"user_token,omitempty" yaml:"user_token,omitempty"`
+	UserTokenIdent string `json:"user_token_ident,omitempty" yaml:"user_token_ident,omitempty"`
+	Enabled        bool   `json:"enabled" yaml:"enabled"`
+	Comment        string `json:"comment,omitempty" yaml:"comment,omitempty"`
+	CreatedAt      int    `json:"created_at,omitempty" yaml:"created_at,omitempty"`
+}
+
+type RBACUsers struct {
+	Data   []*RBACUser `json:"data,omitempty" yaml:"data,omitempty"`
+	Next   string      `json:"next,omitempty" yaml:"next,omitempty"`
+	Offset string      `json:"offset,omitempty" yaml:"offset,omitempty"`
+}
+
+type RBACRoleRequest struct {
+	Name      string `json:"name,omitempty" yaml:"name,omitempty"`
+	Comment   string `json:"comment,omitempty" yaml:"comment,omitempty"`
+	IsDefault *bool  `json:"is_default,omitempty" yaml:"is_default,omitempty"`
+}
+
+type RBACRole struct {
+	Id        string `json:"id,omitempty" yaml:"id,omitempty"`
+	Name      string `json:"name,omitempty" yaml:"name,omitempty"`
+	Comment   string `json:"comment,omitempty" yaml:"comment,omitempty"`
+	IsDefault bool   `json:"is_default" yaml:"is_default"`
+	CreatedAt int    `json:"created_at,omitempty" yaml:"created_at,omitempty"`
+}
+
+type RBACRoles struct {
+	Data   []*RBACRole `json:"data,omitempty" yaml:"data,omitempty"`
+	Next   string      `json:"next,omitempty" yaml:"next,omitempty"`
+	Offset string      `json:"offset,omitempty" yaml:"offset,omitempty"`
+}
+
+type RBACUserRolesRequest struct {
+	Roles string `json:"roles" yaml:"roles"`
+}
+
+type RBACUserRoles struct {
+	User  *RBACUser   `json:"user,omitempty" yaml:"user,omitempty"`
+	Roles []*RBACRole `json:"roles,omitempty" yaml:"roles,omitempty"`
+}
+
+// RBACEndpointPermissionRequest grants or, when negative, denies the actions on an endpoint of a workspace. Actions is
+// a comma separated list of read, create, update and delete, or * for all of them.
+type RBACEndpointPermissionRequest struct {
+	Workspace string `json:"workspace,omitempty" yaml:"workspace,omitempty"`
+	Endpoint  string `json:"endpoint,omitempty" yaml:"endpoint,omitempty"`
+	Actions   string `json:"actions,omitempty" yaml:"actions,omitempty"`
+	Negative  *bool  `json:"negative,omitempty" yaml:"negative,omitempty"`
+	Comment   string `json:"comment,omitempty" yaml:"comment,omitempty"`
+}
+
+type RBACEndpointPermission struct {
+	Workspace string   `json:"workspace,omitempty" yaml:"workspace,omitempty"`
+	Endpoint  string   `json:"endpoint,omitempty" yaml:"endpoint,omitempty"`
+	Actions   []string `json:"actions,omitempty" yaml:"actions,omitempty"`
+	Negative  bool     `json:"negative" yaml:"negative"`
+	Comment   string   `json:"comment,omitempty" yaml:"comment,omitempty"`
+	Role      *Id      `json:"role,omitempty" yaml:"role,omitempty"`
+	CreatedAt int      `json:"created_at,omitempty" yaml:"created_at,omitempty"`
+}
+
+type RBACEndpointPermissions struct {
+	Data   []*RBACEndpointPermission `json:"data,omitempty" yaml:"data,omitempty"`
+	Next   string                    `json:"next,omitempty" yaml:"next,omitempty"`
+	Offset string                    `json:"offset,omitempty" yaml:"offset,omitempty"`
+}
+
+// RBACEntityPermissionRequest grants or, when negative, denies the actions on a single entity, e.g. a service. Actions
+// is a comma separated list of read, create, update and delete, or * for all of them.
+type RBACEntityPermissionRequest struct {
+	EntityId   string `json:"entity_id,omitempty" yaml:"entity_id,omitempty"`
+	EntityType string `json:"entity_type,omitempty" yaml:"entity_type,omitempty"`
+	Actions    string `json:"actions,omitempty" yaml:"actions,omitempty"`
+	Negative   *bool  `json:"negative,omitempty" yaml:"negative,omitempty"`
+	Comment    string `json:"comment,omitempty" yaml:"comment,omitempty"`
+}
+
+type RBACEntityPermission struct {
+	EntityId   string   `json:"entity_id,omitempty" yaml:"entity_id,omitempty"`
+	EntityType string   `json:"entity_type,omitempty" yaml:"entity_type,omitempty"`
+	Actions    []string `json:"actions,omitempty" yaml:"actions,omitempty"`
+	Negative   bool     `json:"negative" yaml:"negative"`
+	Comment    string   `json:"comment,omitempty" yaml:"comment,omitempty"`
+	Role       *Id      `json:"role,omitempty" yaml:"role,omitempty"`
+	CreatedAt  int      `json:"created_at,omitempty" yaml:"created_at,omitempty"`
+}
+
+type RBACEntityPermissions struct {
+	Data   []*RBACEntityPermission `json:"data,omitempty" yaml:"data,omitempty"`
+	Next   string                  `json:"next,omitempty" yaml:"next,omitempty"`
+	Offset string                  `json:"offset,omitempty" yaml:"offset,omitempty"`
+}
+
+type RBACQueryString struct {
+	Offset string `json:"offset,omitempty"`
+	Size   int    `json:"size"`
+}
+
+const RBACUsersPath = "/rbac/users/"
+const RBACRolesPath = "/rbac/roles/"
+
+func (rbacClient *rbacClient) CreateUser(userRequest *RBACUserRequest) (*RBACUser, error) {
+	r, body, errs := newPost(rbacClient.config, RBACUsersPath).Send(userRequest).End()
+	if errs != nil {
+		return nil, fmt.Errorf("could not create new rbac user, error: %v", errs)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	createdUser := &RBACUser{}
+	err := json.Unmarshal([]byte(body), createdUser)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse rbac user creation response, error: %v", err)
+	}
+
+	if createdUser.Id == "" {
+		return nil, fmt.Errorf("could not create rbac user, error: %v", body)
+	}
+
+	return createdUser, nil
+}
+
+func (rbacClient *rbacClient) GetUser(nameOrId string) (*RBACUser, error) {
+	r, body, errs := newGet(rbacClient.config, RBACUsersPath+nameOrId).End()
+	if errs != nil {
+		return nil, fmt.Errorf("could not get rbac user, error: %v", errs)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	user := &RBACUser{}
+	err := json.Unmarshal([]byte(body), user)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse rbac user get response, error: %v", err)
+	}
+
+	if user.Id == "" {
+		return nil, nil
+	}
+
+	return user, nil
+}
+
+func (rbacClient *rbacClient) ListUsers(query *RBACQueryString) ([]*RBACUser, error) {
+	users := make([]*RBACUser, 0)
+
+	pageQuery := RBACQueryString{}
+	if query != nil {
+		pageQuery = *query
+	}
+
+	if pageQuery.Size < 100 {
+		pageQuery.Size = 100
+	}
+
+	if pageQuery.Size > 1000 {
+		pageQuery.Size = 1000
+	}
+
+	for {
+		data := &RBACUsers{}
+
+		r, body, errs := newGet(rbacClient.config, RBACUsersPath).Query(pageQuery).End()
+		if errs != nil {
+			return nil, fmt.Errorf("could not get rbac users, error: %v", errs)
+		}
+
+		if r.StatusCode == 401 || r.StatusCode == 403 {
+			return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+		}
+
+		err := json.Unmarshal([]byte(body), data)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse rbac users list response, error: %v", err)
+		}
+
+		users = append(users, data.Data...)
+		if data.Next == "" || data.Offset == "" {
+			break
+		}
+
+		pageQuery.Offset = data.Offset
+	}
+
+	return users, nil
+}
+
+func (rbacClient *rbacClient) UpdateUser(nameOrId string, userRequest *RBACUserRequest) (*RBACUser, error) {
+	r, body, errs := newPatch(rbacClient.config, RBACUsersPath+nameOrId).Send(userRequest).End()
+	if errs != nil {
+		return nil, fmt.Errorf("could not update rbac user, error: %v", errs)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	updatedUser := &RBACUser{}
+	err := json.Unmarshal([]byte(body), updatedUser)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse rbac user update response, error: %v", err)
+	}
+
+	if updatedUser.Id == "" {
+		return nil, fmt.Errorf("could not update rbac user, error: %v", body)
+	}
+
+	return updatedUser, nil
+}
+
+func (rbacClient *rbacClient) DeleteUser(nameOrId string) error {
+	r, body, errs := newDelete(rbacClient.config, RBACUsersPath+nameOrId).End()
+	if errs != nil {
+		return fmt.Errorf("could not delete rbac user, result: %v error: %v", r, errs)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	return nil
+}
+
+func (rbacClient *rbacClient) GetUserRoles(userNameOrId string) ([]*RBACRole, error) {
+	r, body, errs := newGet(rbacClient.config, RBACUsersPath+userNameOrId+"/roles").End()
+	if errs != nil {
+		return nil, fmt.Errorf("could not get rbac user roles, error: %v", errs)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	if r.StatusCode == 404 {
+		return nil, nil
+	}
+
+	userRoles := &RBACUserRoles{}
+	err := json.Unmarshal([]byte(body), userRoles)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse rbac user roles response, error: %v", err)
+	}
+
+	return userRoles.Roles, nil
+}
+
+func (rbacClient *rbacClient) AddUserRoles(userNameOrId string, roles []string) ([]*RBACRole, error) {
+	rolesRequest := &RBACUserRolesRequest{Roles: strings.Join(roles, ",")}
+
+	r, body, errs := newPost(rbacClient.config, RBACUsersPath+userNameOrId+"/roles").Send(rolesRequest).End()
+	if errs != nil {
+		return nil, fmt.Errorf("could not add roles to rbac user, error: %v", errs)
+	}
+
+	if r.StatusCode == 400 {
+		return nil, fmt.Errorf("bad request, message from kong: %s", body)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	userRoles := &RBACUserRoles{}
+	err := json.Unmarshal([]byte(body), userRoles)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse rbac user roles response, error: %v", err)
+	}
+
+	if userRoles.User == nil {
+		return nil, fmt.Errorf("could not add roles to rbac user, error: %v", body)
+	}
+
+	return userRoles.Roles, nil
+}
+
+func (rbacClient *rbacClient) DeleteUserRoles(userNameOrId string, roles []string) error {
+	rolesRequest := &RBACUserRolesRequest{Roles: strings.Join(roles, ",")}
+
+	r, body, errs := newDelete(rbacClient.config, RBACUsersPath+userNameOrId+"/roles").Send(rolesRequest).End()
+	if errs != nil {
+		return fmt.Errorf("could not delete roles from rbac user, error: %v", errs)
+	}
+
+	if r.StatusCode == 400 {
+		return fmt.Errorf("bad request, message from kong: %s", body)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	return nil
+}
+
+func (rbacClient *rbacClient) CreateRole(roleRequest *RBACRoleRequest) (*RBACRole, error) {
+	r, body, errs := newPost(rbacClient.config, RBACRolesPath).Send(roleRequest).End()
+	if errs != nil {
+		return nil, fmt.Errorf("could not create new rbac role, error: %v", errs)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	createdRole := &RBACRole{}
+	err := json.Unmarshal([]byte(body), createdRole)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse rbac role creation response, error: %v", err)
+	}
+
+	if createdRole.Id == "" {
+		return nil, fmt.Errorf("could not create rbac role, error: %v", body)
+	}
+
+	return createdRole, nil
+}
+
+func (rbacClient *rbacClient) GetRole(nameOrId string) (*RBACRole, error) {
+	r, body, errs := newGet(rbacClient.config, RBACRolesPath+nameOrId).End()
+	if errs != nil {
+		return nil, fmt.Errorf("could not get rbac role, error: %v", errs)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	role := &RBACRole{}
+	err := json.Unmarshal([]byte(body), role)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse rbac role get response, error: %v", err)
+	}
+
+	if role.Id == "" {
+		return nil, nil
+	}
+
+	return role, nil
+}
+
+func (rbacClient *rbacClient) ListRoles(query *RBACQueryString) ([]*RBACRole, error) {
+	roles := make([]*RBACRole, 0)
+
+	pageQuery := RBACQueryString{}
+	if query != nil {
+		pageQuery = *query
+	}
+
+	if pageQuery.Size < 100 {
+		pageQuery.Size = 100
+	}
+
+	if pageQuery.Size > 1000 {
+		pageQuery.Size = 1000
+	}
+
+	for {
+		data := &RBACRoles{}
+
+		r, body, errs := newGet(rbacClient.config, RBACRolesPath).Query(pageQuery).End()
+		if errs != nil {
+			return nil, fmt.Errorf("could not get rbac roles, error: %v", errs)
+		}
+
+		if r.StatusCode == 401 || r.StatusCode == 403 {
+			return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+		}
+
+		err := json.Unmarshal([]byte(body), data)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse rbac roles list response, error: %v", err)
+		}
+
+		roles = append(roles, data.Data...)
+		if data.Next == "" || data.Offset == "" {
+			break
+		}
+
+		pageQuery.Offset = data.Offset
+	}
+
+	return roles, nil
+}
+
+func (rbacClient *rbacClient) UpdateRole(nameOrId string, roleRequest *RBACRoleRequest) (*RBACRole, error) {
+	r, body, errs := newPatch(rbacClient.config, RBACRolesPath+nameOrId).Send(roleRequest).End()
+	if errs != nil {
+		return nil, fmt.Errorf("could not update rbac role, error: %v", errs)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	updatedRole := &RBACRole{}
+	err := json.Unmarshal([]byte(body), updatedRole)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse rbac role update response, error: %v", err)
+	}
+
+	if updatedRole.Id == "" {
+		return nil, fmt.Errorf("could not update rbac role, error: %v", body)
+	}
+
+	return updatedRole, nil
+}
+
+func (rbacClient *rbacClient) DeleteRole(nameOrId string) error {
+	r, body, errs := newDelete(rbacClient.config, RBACRolesPath+nameOrId).End()
+	if errs != nil {
+		return fmt.Errorf("could not delete rbac role, result: %v error: %v", r, errs)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	return nil
+}
+
+func (rbacClient *rbacClient) CreateEndpointPermission(roleNameOrId string, permissionRequest *RBACEndpointPermissionRequest) (*RBACEndpointPermission, error) {
+	r, body, errs := newPost(rbacClient.config, RBACRolesPath+roleNameOrId+"/endpoints").Send(permissionRequest).End()
+	if errs != nil {
+		return nil, fmt.Errorf("could not create rbac endpoint permission, error: %v", errs)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	createdPermission := &RBACEndpointPermission{}
+	err := json.Unmarshal([]byte(body), createdPermission)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse rbac endpoint permission creation response, error: %v", err)
+	}
+
+	if createdPermission.Endpoint == "" {
+		return nil, fmt.Errorf("could not create rbac endpoint permission, error: %v", body)
+	}
+
+	return createdPermission, nil
+}
+
+func (rbacClient *rbacClient) GetEndpointPermission(roleNameOrId string, workspace string, endpoint string) (*RBACEndpointPermission, error) {
+	r, body, errs := newGet(rbacClient.config, endpointPermissionPath(roleNameOrId, workspace, endpoint)).End()
+	if errs != nil {
+		return nil, fmt.Errorf("could not get rbac endpoint permission, error: %v", errs)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	permission := &RBACEndpointPermission{}
+	err := json.Unmarshal([]byte(body), permission)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse rbac endpoint permission get response, error: %v", err)
+	}
+
+	if permission.Endpoint == "" {
+		return nil, nil
+	}
+
+	return permission, nil
+}
+
+func (rbacClient *rbacClient) ListEndpointPermissions(roleNameOrId string) ([]*RBACEndpointPermission, error) {
+	permissions := make([]*RBACEndpointPermission, 0)
+	query := &RBACQueryString{Size: 1000}
+
+	for {
+		data := &RBACEndpointPermissions{}
+
+		r, body, errs := newGet(rbacClient.config, RBACRolesPath+roleNameOrId+"/endpoints").Query(*query).End()
+		if errs != nil {
+			return nil, fmt.Errorf("could not get rbac endpoint permissions, error: %v", errs)
+		}
+
+		if r.StatusCode == 401 || r.StatusCode == 403 {
+			return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+		}
+
+		err := json.Unmarshal([]byte(body), data)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse rbac endpoint permissions list response, error: %v", err)
+		}
+
+		permissions = append(permissions, data.Data...)
+		if data.Next == "" || data.Offset == "" {
+			break
+		}
+
+		query.Offset = data.Offset
+	}
+
+	return permissions, nil
+}
+
+func (rbacClient *rbacClient) UpdateEndpointPermission(roleNameOrId string, workspace string, endpoint string, permissionRequest *RBACEndpointPermissionRequest) (*RBACEndpointPermission, error) {
+	r, body, errs := newPatch(rbacClient.config, endpointPermissionPath(roleNameOrId, workspace, endpoint)).Send(permissionRequest).End()
+	if errs != nil {
+		return nil, fmt.Errorf("could not update rbac endpoint permission, error: %v", errs)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	updatedPermission := &RBACEndpointPermission{}
+	err := json.Unmarshal([]byte(body), updatedPermission)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse rbac endpoint permission update response, error: %v", err)
+	}
+
+	if updatedPermission.Endpoint == "" {
+		return nil, fmt.Errorf("could not update rbac endpoint permission, error: %v", body)
+	}
+
+	return updatedPermission, nil
+}
+
+func (rbacClient *rbacClient) DeleteEndpointPermission(roleNameOrId string, workspace string, endpoint string) error {
+	r, body, errs := newDelete(rbacClient.config, endpointPermissionPath(roleNameOrId, workspace, endpoint)).End()
+	if errs != nil {
+		return fmt.Errorf("could not delete rbac endpoint permission, result: %v error: %v", r, errs)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	return nil
+}
+
+func (rbacClient *rbacClient) CreateEntityPermission(roleNameOrId string, permissionRequest *RBACEntityPermissionRequest) (*RBACEntityPermission, error) {
+	r, body, errs := newPost(rbacClient.config, RBACRolesPath+roleNameOrId+"/entities").Send(permissionRequest).End()
+	if errs != nil {
+		return nil, fmt.Errorf("could not create rbac entity permission, error: %v", errs)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	createdPermission := &RBACEntityPermission{}
+	err := json.Unmarshal([]byte(body), createdPermission)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse rbac entity permission creation response, error: %v", err)
+	}
+
+	if createdPermission.EntityId == "" {
+		return nil, fmt.Errorf("could not create rbac entity permission, error: %v", body)
+	}
+
+	return createdPermission, nil
+}
+
+func (rbacClient *rbacClient) GetEntityPermission(roleNameOrId string, entityId string) (*RBACEntityPermission, error) {
+	r, body, errs := newGet(rbacClient.config, RBACRolesPath+roleNameOrId+"/entities/"+entityId).End()
+	if errs != nil {
+		return nil, fmt.Errorf("could not get rbac entity permission, error: %v", errs)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	permission := &RBACEntityPermission{}
+	err := json.Unmarshal([]byte(body), permission)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse rbac entity permission get response, error: %v", err)
+	}
+
+	if permission.EntityId == "" {
+		return nil, nil
+	}
+
+	return permission, nil
+}
+
+func (rbacClient *rbacClient) ListEntityPermissions(roleNameOrId string) ([]*RBACEntityPermission, error) {
+	permissions := make([]*RBACEntityPermission, 0)
+	query := &RBACQueryString{Size: 1000}
+
+	for {
+		data := &RBACEntityPermissions{}
+
+		r, body, errs := newGet(rbacClient.config, RBACRolesPath+roleNameOrId+"/entities").Query(*query).End()
+		if errs != nil {
+			return nil, fmt.Errorf("could not get rbac entity permissions, error: %v", errs)
+		}
+
+		if r.StatusCode == 401 || r.StatusCode == 403 {
+			return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+		}
+
+		err := json.Unmarshal([]byte(body), data)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse rbac entity permissions list response, error: %v", err)
+		}
+
+		permissions = append(permissions, data.Data...)
+		if data.Next == "" || data.Offset == "" {
+			break
+		}
+
+		query.Offset = data.Offset
+	}
+
+	return permissions, nil
+}
+
+func (rbacClient *rbacClient) UpdateEntityPermission(roleNameOrId string, entityId string, permissionRequest *RBACEntityPermissionRequest) (*RBACEntityPermission, error) {
+	r, body, errs := newPatch(rbacClient.config, RBACRolesPath+roleNameOrId+"/entities/"+entityId).Send(permissionRequest).End()
+	if errs != nil {
+		return nil, fmt.Errorf("could not update rbac entity permission, error: %v", errs)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	updatedPermission := &RBACEntityPermission{}
+	err := json.Unmarshal([]byte(body), updatedPermission)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse rbac entity permission update response, error: %v", err)
+	}
+
+	if updatedPermission.EntityId == "" {
+		return nil, fmt.Errorf("could not update rbac entity permission, error: %v", body)
+	}
+
+	return updatedPermission, nil
+}
+
+func (rbacClient *rbacClient) DeleteEntityPermission(roleNameOrId string, entityId string) error {
+	r, body, errs := newDelete(rbacClient.config, RBACRolesPath+roleNameOrId+"/entities/"+entityId).End()
+	if errs != nil {
+		return fmt.Errorf("could not delete rbac entity permission, result: %v error: %v", r, errs)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	return nil
+}
+
+// endpointPermissionPath builds the path of an endpoint permission, which ends with the endpoint itself, e.g.
+// /rbac/roles/{role}/endpoints/default/services/ for the /services/ endpoint of the default workspace.
+func endpointPermissionPath(roleNameOrId string, workspace string, endpoint string) string {
+	if !strings.HasPrefix(endpoint, "/") {
+		endpoint = "/" + endpoint
+	}
+	return fmt.Sprintf("%s%s/endpoints/%s%s", RBACRolesPath, roleNameOrId, workspace, endpoint)
+}
@@ -0,0 +1,37 @@
+package gokong
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// pageWithoutOffset is a page whose next link has no offset, which must end the listing instead of asking for the
+// first page again.
+const pageWithoutOffset = `{"data":[{"id":"1","workspace":"default","endpoint":"/services","entity_id":"1"}],"next":"/page-2"}`
+
+func Test_RBACListsStopWhenKongReturnsNoOffset(t *testing.T) {
+	client, queries := pagedClient(pageWithoutOffset)
+	users, err := client.RBAC().ListUsers(nil)
+	assert.Nil(t, err)
+	assert.Len(t, users, 1)
+	assert.Len(t, *queries, 1)
+
+	client, queries = pagedClient(pageWithoutOffset)
+	roles, err := client.RBAC().ListRoles(nil)
+	assert.Nil(t, err)
+	assert.Len(t, roles, 1)
+	assert.Len(t, *queries, 1)
+
+	client, queries = pagedClient(pageWithoutOffset)
+	endpointPermissions, err := client.RBAC().ListEndpointPermissions("role")
+	assert.Nil(t, err)
+	assert.Len(t, endpointPermissions, 1)
+	assert.Len(t, *queries, 1)
+
+	client, queries = pagedClient(pageWithoutOffset)
+	entityPermissions, err := client.RBAC().ListEntityPermissions("role")
+	assert.Nil(t, err)
+	assert.Len(t, entityPermissions, 1)
+	assert.Len(t, *queries, 1)
+}
@@ -0,0 +1,198 @@
+// +build all enterprise
+
+package gokong
+
+import (
+	"fmt"
+	"testing"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// rbacVersions are the kong enterprise versions with rbac users, roles and permissions.
+const rbacVersions = ">= 0.33"
+
+func Test_RBACClient_Users(t *testing.T) {
+	kongTestContext.SkipUnlessVersion(t, rbacVersions)
+
+	client := NewClient(NewDefaultConfig())
+	userRequest := &RBACUserRequest{
+		Name:      fmt.Sprintf("user-%s", uuid.NewV4().String()),
+		UserToken: uuid.NewV4().String(),
+		Comment:   "a user",
+	}
+
+	createdUser, err := client.RBAC().CreateUser(userRequest)
+	assert.Nil(t, err)
+	assert.NotNil(t, createdUser)
+	assert.Equal(t, userRequest.Name, createdUser.Name)
+	assert.True(t, createdUser.Enabled)
+
+	result, err := client.RBAC().GetUser(userRequest.Name)
+	assert.Nil(t, err)
+	assert.Equal(t, createdUser.Id, result.Id)
+
+	users, err := client.RBAC().ListUsers(&RBACQueryString{})
+	assert.Nil(t, err)
+	assert.Contains(t, users, result)
+
+	updatedUser, err := client.RBAC().UpdateUser(createdUser.Id, &RBACUserRequest{Enabled: Bool(false)})
+	assert.Nil(t, err)
+	assert.False(t, updatedUser.Enabled)
+
+	err = client.RBAC().DeleteUser(createdUser.Id)
+	assert.Nil(t, err)
+
+	result, err = client.RBAC().GetUser(createdUser.Id)
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+}
+
+func Test_RBACClient_UserRoles(t *testing.T) {
+	kongTestContext.SkipUnlessVersion(t, rbacVersions)
+
+	client := NewClient(NewDefaultConfig())
+	createdUser, err := client.RBAC().CreateUser(&RBACUserRequest{
+		Name:      fmt.Sprintf("user-%s", uuid.NewV4().String()),
+		UserToken: uuid.NewV4().String(),
+	})
+	assert.Nil(t, err)
+	createdRole, err := client.RBAC().CreateRole(&RBACRoleRequest{Name: fmt.Sprintf("role-%s", uuid.NewV4().String())})
+	assert.Nil(t, err)
+
+	roles, err := client.RBAC().AddUserRoles(createdUser.Name, []string{createdRole.Name})
+	assert.Nil(t, err)
+	assert.Len(t, roles, 1)
+	assert.Equal(t, createdRole.Id, roles[0].Id)
+
+	roles, err = client.RBAC().GetUserRoles(createdUser.Name)
+	assert.Nil(t, err)
+	assert.Len(t, roles, 1)
+
+	err = client.RBAC().DeleteUserRoles(createdUser.Name, []string{createdRole.Name})
+	assert.Nil(t, err)
+
+	roles, err = client.RBAC().GetUserRoles(createdUser.Name)
+	assert.Nil(t, err)
+	assert.Len(t, roles, 0)
+
+	assert.Nil(t, client.RBAC().DeleteUser(createdUser.Id))
+	assert.Nil(t, client.RBAC().DeleteRole(createdRole.Id))
+}
+
+func Test_RBACClient_Roles(t *testing.T) {
+	kongTestContext.SkipUnlessVersion(t, rbacVersions)
+
+	client := NewClient(NewDefaultConfig())
+	roleRequest := &RBACRoleRequest{Name: fmt.Sprintf("role-%s", uuid.NewV4().String())}
+
+	createdRole, err := client.RBAC().CreateRole(roleRequest)
+	assert.Nil(t, err)
+	assert.Equal(t, roleRequest.Name, createdRole.Name)
+
+	result, err := client.RBAC().GetRole(roleRequest.Name)
+	assert.Nil(t, err)
+	assert.Equal(t, createdRole, result)
+
+	roles, err := client.RBAC().ListRoles(&RBACQueryString{})
+	assert.Nil(t, err)
+	assert.Contains(t, roles, result)
+
+	updatedRole, err := client.RBAC().UpdateRole(createdRole.Id, &RBACRoleRequest{Comment: "a comment"})
+	assert.Nil(t, err)
+	assert.Equal(t, "a comment", updatedRole.Comment)
+
+	err = client.RBAC().DeleteRole(createdRole.Id)
+	assert.Nil(t, err)
+
+	result, err = client.RBAC().GetRole(createdRole.Id)
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+}
+
+func Test_RBACClient_EndpointPermissions(t *testing.T) {
+	kongTestContext.SkipUnlessVersion(t, rbacVersions)
+
+	client := NewClient(NewDefaultConfig())
+	createdRole, err := client.RBAC().CreateRole(&RBACRoleRequest{Name: fmt.Sprintf("role-%s", uuid.NewV4().String())})
+	assert.Nil(t, err)
+
+	permission, err := client.RBAC().CreateEndpointPermission(createdRole.Id, &RBACEndpointPermissionRequest{
+		Workspace: "default",
+		Endpoint:  "/services/",
+		Actions:   "read,update",
+	})
+	assert.Nil(t, err)
+	assert.NotNil(t, permission)
+	assert.ElementsMatch(t, []string{"read", "update"}, permission.Actions)
+	assert.Equal(t, createdRole.Id, IdToString(permission.Role))
+
+	result, err := client.RBAC().GetEndpointPermission(createdRole.Id, "default", "/services/")
+	assert.Nil(t, err)
+	assert.Equal(t, permission.Actions, result.Actions)
+
+	permissions, err := client.RBAC().ListEndpointPermissions(createdRole.Id)
+	assert.Nil(t, err)
+	assert.Len(t, permissions, 1)
+
+	updatedPermission, err := client.RBAC().UpdateEndpointPermission(createdRole.Id, "default", "/services/", &RBACEndpointPermissionRequest{
+		Negative: Bool(true),
+	})
+	assert.Nil(t, err)
+	assert.True(t, updatedPermission.Negative)
+
+	err = client.RBAC().DeleteEndpointPermission(createdRole.Id, "default", "/services/")
+	assert.Nil(t, err)
+
+	result, err = client.RBAC().GetEndpointPermission(createdRole.Id, "default", "/services/")
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+
+	assert.Nil(t, client.RBAC().DeleteRole(createdRole.Id))
+}
+
+func Test_RBACClient_EntityPermissions(t *testing.T) {
+	kongTestContext.SkipUnlessVersion(t, rbacVersions)
+
+	client := NewClient(NewDefaultConfig())
+	createdRole, err := client.RBAC().CreateRole(&RBACRoleRequest{Name: fmt.Sprintf("role-%s", uuid.NewV4().String())})
+	assert.Nil(t, err)
+	createdService, err := client.Services().Create(&ServiceRequest{
+		Name: String(fmt.Sprintf("service-name-%s", uuid.NewV4().String())),
+		Host: String("foo.com"),
+	})
+	assert.Nil(t, err)
+
+	permission, err := client.RBAC().CreateEntityPermission(createdRole.Id, &RBACEntityPermissionRequest{
+		EntityId: *createdService.Id,
+		Actions:  "read",
+	})
+	assert.Nil(t, err)
+	assert.NotNil(t, permission)
+	assert.Equal(t, "services", permission.EntityType)
+
+	result, err := client.RBAC().GetEntityPermission(createdRole.Id, *createdService.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"read"}, result.Actions)
+
+	permissions, err := client.RBAC().ListEntityPermissions(createdRole.Id)
+	assert.Nil(t, err)
+	assert.Len(t, permissions, 1)
+
+	updatedPermission, err := client.RBAC().UpdateEntityPermission(createdRole.Id, *createdService.Id, &RBACEntityPermissionRequest{
+		Comment: "read only",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "read only", updatedPermission.Comment)
+
+	err = client.RBAC().DeleteEntityPermission(createdRole.Id, *createdService.Id)
+	assert.Nil(t, err)
+
+	result, err = client.RBAC().GetEntityPermission(createdRole.Id, *createdService.Id)
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+
+	assert.Nil(t, client.RBAC().DeleteRole(createdRole.Id))
+	assert.Nil(t, client.Services().DeleteServiceById(*createdService.Id))
+}
@@ -3,11 +3,129 @@ package gokong
 import (
 	"crypto/tls"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/parnurzeal/gorequest"
 )
 
-func configureRequest(r *gorequest.SuperAgent, config *Config) *gorequest.SuperAgent {
+// request wraps a gorequest.SuperAgent so that requests are sent through the http client of the config.
+type request struct {
+	*gorequest.SuperAgent
+	config *Config
+	path   string
+}
+
+func (r *request) Send(content interface{}) *request {
+	r.SuperAgent.Send(content)
+	return r
+}
+
+func (r *request) Query(content interface{}) *request {
+	r.SuperAgent.Query(content)
+	return r
+}
+
+// End sends the request and returns the response with its body, it replaces gorequest's End so that the
+// http client configured in Config.HTTPClient is used.
+func (r *request) End() (gorequest.Response, string, []error) {
+	if len(r.Errors) != 0 {
+		return nil, "", r.Errors
+	}
+
+	req, err := r.MakeRequest()
+	if err != nil {
+		return nil, "", []error{err}
+	}
+
+	if resp, body, ok := r.config.Cache.get(r.path, req); ok {
+		return resp, body, nil
+	}
+
+	// the limiter is waited for before the instrumentation is notified, so the time spent queueing is not measured as
+	// the time of the request and requests that were never sent are not reported
+	ctx := r.config.context()
+	release, err := r.config.Limiter.wait(ctx)
+	if err != nil {
+		return nil, "", []error{err}
+	}
+	defer release()
+
+	instrumentation := r.config.Instrumentation
+	var info *RequestInfo
+	if instrumentation != nil {
+		info = newRequestInfo(r.config, req.Method, r.path, req.Header)
+		ctx = instrumentation.StartRequest(ctx, info)
+	}
+	req = req.WithContext(ctx)
+
+	start := time.Now()
+	resp, err := r.httpClient().Do(req)
+	if err != nil {
+		r.log(req, nil, time.Since(start), err)
+		if instrumentation != nil {
+			instrumentation.EndRequest(ctx, info, 0, err)
+		}
+		r.config.Cache.update(r.config.Workspace, r.path, req, nil, "")
+		return nil, "", []error{err}
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	r.log(req, resp, time.Since(start), err)
+	if instrumentation != nil {
+		instrumentation.EndRequest(ctx, info, resp.StatusCode, err)
+	}
+	if err != nil {
+		r.config.Cache.update(r.config.Workspace, r.path, req, nil, "")
+		return nil, "", []error{err}
+	}
+
+	r.config.Cache.update(r.config.Workspace, r.path, req, resp, string(body))
+	return resp, string(body), nil
+}
+
+// log sends the exchange to the logger of the config, the credentials in the request headers are redacted. The request
+// body is only logged when the config asks for it, as it can carry credentials.
+func (r *request) log(req *http.Request, resp *http.Response, duration time.Duration, err error) {
+	if r.config.Logger == nil {
+		return
+	}
+
+	keysAndValues := []interface{}{
+		"method", req.Method,
+		"url", req.URL.String(),
+		"request_headers", redactHeaders(req.Header),
+	}
+	if r.config.LogRequestBody && req.GetBody != nil {
+		if reqBody, bodyErr := req.GetBody(); bodyErr == nil {
+			content, _ := ioutil.ReadAll(reqBody)
+			keysAndValues = append(keysAndValues, "request_body", string(content))
+		}
+	}
+	if resp != nil {
+		keysAndValues = append(keysAndValues, "status", resp.StatusCode)
+	}
+	keysAndValues = append(keysAndValues, "duration", duration)
+	if err != nil {
+		keysAndValues = append(keysAndValues, "error", err)
+	}
+
+	r.config.Logger.Debug("kong admin api request", keysAndValues...)
+}
+
+func (r *request) httpClient() *http.Client {
+	if r.config.HTTPClient != nil {
+		return r.config.HTTPClient
+	}
+	r.Client.Transport = r.Transport
+	r.Client.Timeout = r.config.Timeout
+	return r.Client
+}
+
+func configureRequest(r *gorequest.SuperAgent, config *Config, path string) *request {
 	r.TLSClientConfig(&tls.Config{InsecureSkipVerify: config.InsecureSkipVerify})
 	if config.Username != "" || config.Password != "" {
 		r.SetBasicAuth(config.Username, config.Password)
@@ -21,7 +139,11 @@ func configureRequest(r *gorequest.SuperAgent, config *Config) *gorequest.SuperA
 		r.Set("kong-admin-token", config.AdminToken)
 	}
 
-	return r
+	if config.UserAgent != "" {
+		r.Set("User-Agent", config.UserAgent)
+	}
+
+	return &request{SuperAgent: r, config: config, path: path}
 }
 
 func buildRequestUri(config *Config, path string) string {
@@ -31,42 +153,42 @@ func buildRequestUri(config *Config, path string) string {
 	return fmt.Sprintf("%s/%s%s", config.HostAddress, config.Workspace, path)
 }
 
-func newRawGet(config *Config, address string) *gorequest.SuperAgent {
+func newRawGet(config *Config, address string) *request {
 	r := gorequest.New().Get(address)
-	return configureRequest(r, config)
+	return configureRequest(r, config, strings.TrimPrefix(address, config.HostAddress))
 }
 
-func newRawPost(config *Config, address string) *gorequest.SuperAgent {
+func newRawPost(config *Config, address string) *request {
 	r := gorequest.New().Post(address)
-	return configureRequest(r, config)
+	return configureRequest(r, config, strings.TrimPrefix(address, config.HostAddress))
 }
 
-func newRawPatch(config *Config, address string) *gorequest.SuperAgent {
+func newRawPatch(config *Config, address string) *request {
 	r := gorequest.New().Patch(address)
-	return configureRequest(r, config)
+	return configureRequest(r, config, strings.TrimPrefix(address, config.HostAddress))
 }
 
-func newRawDelete(config *Config, address string) *gorequest.SuperAgent {
+func newRawDelete(config *Config, address string) *request {
 	r := gorequest.New().Delete(address)
-	return configureRequest(r, config)
+	return configureRequest(r, config, strings.TrimPrefix(address, config.HostAddress))
 }
 
-func newGet(config *Config, path string) *gorequest.SuperAgent {
+func newGet(config *Config, path string) *request {
 	r := gorequest.New().Get(buildRequestUri(config, path))
-	return configureRequest(r, config)
+	return configureRequest(r, config, path)
 }
 
-func newPost(config *Config, path string) *gorequest.SuperAgent {
+func newPost(config *Config, path string) *request {
 	r := gorequest.New().Post(buildRequestUri(config, path))
-	return configureRequest(r, config)
+	return configureRequest(r, config, path)
 }
 
-func newPatch(config *Config, path string) *gorequest.SuperAgent {
+func newPatch(config *Config, path string) *request {
 	r := gorequest.New().Patch(buildRequestUri(config, path))
-	return configureRequest(r, config)
+	return configureRequest(r, config, path)
 }
 
-func newDelete(config *Config, path string) *gorequest.SuperAgent {
+func newDelete(config *Config, path string) *request {
 	r := gorequest.New().Delete(buildRequestUri(config, path))
-	return configureRequest(r, config)
+	return configureRequest(r, config, path)
 }
@@ -0,0 +1,70 @@
+package gokong
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func Test_RequestUsesConfiguredHttpClient(t *testing.T) {
+	var sent *http.Request
+	config := &Config{
+		HostAddress: "http://kong:8001",
+		AdminToken:  "token",
+		HTTPClient: &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			sent = req
+			return &http.Response{
+				StatusCode: 200,
+				Header:     http.Header{},
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"id":"123","username":"user"}`)),
+			}, nil
+		})},
+	}
+
+	result, err := NewClient(config).Consumers().GetByUsername("user")
+
+	assert.Nil(t, err)
+	assert.Equal(t, &Consumer{Id: "123", Username: "user"}, result)
+	assert.Equal(t, "http://kong:8001/consumers/user", sent.URL.String())
+	assert.Equal(t, "token", sent.Header.Get("kong-admin-token"))
+}
+
+func Test_InWorkspaceSendsRequestsToWorkspace(t *testing.T) {
+	paths := make([]string, 0)
+	httpClient := &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		paths = append(paths, req.URL.Path)
+		return &http.Response{StatusCode: 404, Header: http.Header{}, Body: ioutil.NopCloser(bytes.NewBufferString(`{}`))}, nil
+	})}
+	config := &Config{HostAddress: "http://kong:8001", HTTPClient: httpClient}
+	client := NewClient(config)
+
+	teamClient := client.InWorkspace("team-a")
+	_, err := teamClient.Services().GetServiceByName("service")
+	assert.Nil(t, err)
+	_, err = client.Services().GetServiceByName("service")
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{"/team-a/services/service", "/services/service"}, paths)
+	assert.Equal(t, "", config.Workspace)
+	assert.Equal(t, httpClient, teamClient.(*kongAdminClient).config.HTTPClient)
+}
+
+// pagedClient returns a client whose requests are answered with the pages in order, and the queries of the requests.
+func pagedClient(pages ...string) (KongAdminClient, *[]string) {
+	queries := make([]string, 0)
+	client := NewClient(&Config{HostAddress: "http://kong:8001", HTTPClient: &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		page := pages[len(queries)]
+		queries = append(queries, req.URL.RawQuery)
+		return &http.Response{StatusCode: 200, Header: http.Header{}, Body: ioutil.NopCloser(bytes.NewBufferString(page))}, nil
+	})}})
+	return client, &queries
+}
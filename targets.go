@@ -18,6 +18,15 @@ type TargetClient interface {
 	SetTargetFromUpstreamByIdAsUnhealthy(upstreamNameOrId string, id string) error
 	GetTargetsWithHealthFromUpstreamName(name string) ([]*Target, error)
 	GetTargetsWithHealthFromUpstreamId(id string) ([]*Target, error)
+	ListFromUpstream(upstreamNameOrId string, query *TargetQueryString) ([]*Target, error)
+	GetAllTargetsFromUpstreamName(name string) ([]*Target, error)
+	GetAllTargetsFromUpstreamId(id string) ([]*Target, error)
+	GetFromUpstreamByHostPort(upstreamNameOrId string, hostPort string) (*Target, error)
+	GetFromUpstreamById(upstreamNameOrId string, id string) (*Target, error)
+	UpdateFromUpstreamByHostPort(upstreamNameOrId string, hostPort string, targetRequest *TargetRequest) (*Target, error)
+	UpdateFromUpstreamById(upstreamNameOrId string, id string, targetRequest *TargetRequest) (*Target, error)
+	CreateManyFromUpstream(upstreamNameOrId string, targetRequests []*TargetRequest, options *BulkOptions) ([]*Target, error)
+	DeleteManyFromUpstream(upstreamNameOrId string, hostPortsOrIds []string, options *BulkOptions) error
 }
 
 type targetClient struct {
@@ -44,9 +53,28 @@ type Targets struct {
 	Data   []*Target `json:"data" yaml:"data"`
 	Total  int       `json:"total,omitempty" yaml:"total,omitempty"`
 	Next   string    `json:"next,omitempty" yaml:"next,omitempty"`
+	Offset string    `json:"offset,omitempty" yaml:"offset,omitempty"`
 	NodeId string    `json:"node_id,omitempty" yaml:"node_id,omitempty"`
 }
 
+type TargetQueryString struct {
+	Offset string `json:"offset,omitempty"`
+	Size   int    `json:"size"`
+}
+
+// allTarget is the representation of a target returned by the /targets/all endpoint, which refers to its
+// upstream by upstream_id rather than the upstream foreign key returned by the other target endpoints.
+type allTarget struct {
+	Target
+	UpstreamId *string `json:"upstream_id" yaml:"upstream_id"`
+}
+
+type allTargets struct {
+	Data   []*allTarget `json:"data" yaml:"data"`
+	Next   string       `json:"next,omitempty" yaml:"next,omitempty"`
+	Offset string       `json:"offset,omitempty" yaml:"offset,omitempty"`
+}
+
 const TargetsPath = "/upstreams/%s/targets"
 
 func (targetClient *targetClient) CreateFromUpstreamName(name string, targetRequest *TargetRequest) (*Target, error) {
@@ -81,11 +109,29 @@ func (targetClient *targetClient) GetTargetsFromUpstreamName(name string) ([]*Ta
 }
 
 func (targetClient *targetClient) GetTargetsFromUpstreamId(id string) ([]*Target, error) {
-	targets := []*Target{}
-	data := &Targets{}
+	return targetClient.ListFromUpstream(id, &TargetQueryString{})
+}
+
+func (targetClient *targetClient) ListFromUpstream(upstreamNameOrId string, query *TargetQueryString) ([]*Target, error) {
+	targets := make([]*Target, 0)
+
+	pageQuery := TargetQueryString{}
+	if query != nil {
+		pageQuery = *query
+	}
+
+	if pageQuery.Size < 100 {
+		pageQuery.Size = 100
+	}
+
+	if pageQuery.Size > 1000 {
+		pageQuery.Size = 1000
+	}
 
 	for {
-		r, body, errs := newGet(targetClient.config, fmt.Sprintf(TargetsPath, id)).End()
+		data := &Targets{}
+
+		r, body, errs := newGet(targetClient.config, fmt.Sprintf(TargetsPath, upstreamNameOrId)).Query(pageQuery).End()
 		if errs != nil {
 			return nil, fmt.Errorf("could not get targets, error: %v", errs)
 		}
@@ -95,7 +141,7 @@ func (targetClient *targetClient) GetTargetsFromUpstreamId(id string) ([]*Target
 		}
 
 		if r.StatusCode == 404 {
-			return nil, fmt.Errorf("non existent upstream: %s", id)
+			return nil, fmt.Errorf("non existent upstream: %s", upstreamNameOrId)
 		}
 
 		err := json.Unmarshal([]byte(body), data)
@@ -105,13 +151,144 @@ func (targetClient *targetClient) GetTargetsFromUpstreamId(id string) ([]*Target
 
 		targets = append(targets, data.Data...)
 
-		if data.Next == "" {
+		if data.Next == "" || data.Offset == "" {
 			break
 		}
+
+		pageQuery.Offset = data.Offset
 	}
+
 	return targets, nil
 }
 
+func (targetClient *targetClient) GetAllTargetsFromUpstreamName(name string) ([]*Target, error) {
+	return targetClient.GetAllTargetsFromUpstreamId(name)
+}
+
+func (targetClient *targetClient) GetAllTargetsFromUpstreamId(id string) ([]*Target, error) {
+	targets := make([]*Target, 0)
+	query := &TargetQueryString{Size: 100}
+
+	for {
+		data := &allTargets{}
+
+		r, body, errs := newGet(targetClient.config, fmt.Sprintf(TargetsPath, id)+"/all").Query(*query).End()
+		if errs != nil {
+			return nil, fmt.Errorf("could not get targets, error: %v", errs)
+		}
+
+		if r.StatusCode == 401 || r.StatusCode == 403 {
+			return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+		}
+
+		if r.StatusCode == 404 {
+			return nil, fmt.Errorf("non existent upstream: %s", id)
+		}
+
+		if r.StatusCode == 405 {
+			return nil, fmt.Errorf("listing all targets is not supported by this version of kong")
+		}
+
+		err := json.Unmarshal([]byte(body), data)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse target get response, error: %v", err)
+		}
+
+		for _, target := range data.Data {
+			if target.UpstreamId != nil {
+				target.Upstream = ToId(*target.UpstreamId)
+			}
+			targets = append(targets, &target.Target)
+		}
+
+		if data.Next == "" || data.Offset == "" {
+			break
+		}
+
+		query.Offset = data.Offset
+	}
+
+	return targets, nil
+}
+
+func (targetClient *targetClient) GetFromUpstreamByHostPort(upstreamNameOrId string, hostPort string) (*Target, error) {
+	return targetClient.GetFromUpstreamById(upstreamNameOrId, hostPort)
+}
+
+func (targetClient *targetClient) GetFromUpstreamById(upstreamNameOrId string, id string) (*Target, error) {
+	r, body, errs := newGet(targetClient.config, fmt.Sprintf(TargetsPath, upstreamNameOrId)+fmt.Sprintf("/%s", id)).End()
+	if errs != nil {
+		return nil, fmt.Errorf("could not get target, error: %v", errs)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	// Older versions of kong do not support getting a single target so fall back to searching the target list
+	if r.StatusCode == 404 || r.StatusCode == 405 {
+		targets, err := targetClient.ListFromUpstream(upstreamNameOrId, &TargetQueryString{})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, target := range targets {
+			if (target.Id != nil && *target.Id == id) || (target.Target != nil && *target.Target == id) {
+				return target, nil
+			}
+		}
+
+		return nil, nil
+	}
+
+	target := &Target{}
+	err := json.Unmarshal([]byte(body), target)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse target get response, error: %v", err)
+	}
+
+	if target.Id == nil {
+		return nil, nil
+	}
+
+	return target, nil
+}
+
+func (targetClient *targetClient) UpdateFromUpstreamByHostPort(upstreamNameOrId string, hostPort string, targetRequest *TargetRequest) (*Target, error) {
+	return targetClient.UpdateFromUpstreamById(upstreamNameOrId, hostPort, targetRequest)
+}
+
+func (targetClient *targetClient) UpdateFromUpstreamById(upstreamNameOrId string, id string, targetRequest *TargetRequest) (*Target, error) {
+	r, body, errs := newPatch(targetClient.config, fmt.Sprintf(TargetsPath, upstreamNameOrId)+fmt.Sprintf("/%s", id)).Send(targetRequest).End()
+	if errs != nil {
+		return nil, fmt.Errorf("could not update target, error: %v", errs)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	if r.StatusCode == 400 {
+		return nil, fmt.Errorf("bad request, message from kong: %s", body)
+	}
+
+	if r.StatusCode == 405 {
+		return nil, fmt.Errorf("updating targets is not supported by this version of kong, create the target again instead")
+	}
+
+	updatedTarget := &Target{}
+	err := json.Unmarshal([]byte(body), updatedTarget)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse target update response, error: %v", err)
+	}
+
+	if updatedTarget.Id == nil {
+		return nil, fmt.Errorf("could not update target, error: %v", body)
+	}
+
+	return updatedTarget, nil
+}
+
 func (targetClient *targetClient) DeleteFromUpstreamByHostPort(upstreamNameOrId string, hostPort string) error {
 	return targetClient.DeleteFromUpstreamById(upstreamNameOrId, hostPort)
 }
@@ -181,10 +358,12 @@ func (targetClient *targetClient) GetTargetsWithHealthFromUpstreamName(name stri
 
 func (targetClient *targetClient) GetTargetsWithHealthFromUpstreamId(id string) ([]*Target, error) {
 	targets := []*Target{}
-	data := &Targets{}
+	query := &TargetQueryString{Size: 100}
 
 	for {
-		r, body, errs := newGet(targetClient.config, fmt.Sprintf("/upstreams/%s/health", id)).End()
+		data := &Targets{}
+
+		r, body, errs := newGet(targetClient.config, fmt.Sprintf("/upstreams/%s/health", id)).Query(*query).End()
 		if errs != nil {
 			return nil, fmt.Errorf("could not get targets, error: %v", errs)
 		}
@@ -204,16 +383,32 @@ func (targetClient *targetClient) GetTargetsWithHealthFromUpstreamId(id string)
 
 		targets = append(targets, data.Data...)
 
-		if data.Next == "" {
+		if data.Next == "" || data.Offset == "" {
 			break
 		}
+
+		query.Offset = data.Offset
 	}
 	return targets, nil
 }
 
-// TODO: Implement List all Targets - https://docs.konghq.com/1.0.x/admin-api/#list-all-targets
-// Note: JSON returned by this method has slightly different structure to the standard Get request. This method retruns "upstream_id": "07131005-ba30-4204-a29f-0927d53257b4" instead of "upstream": {"id":"127dfc88-ed57-45bf-b77a-a9d3a152ad31"},
+// CreateManyFromUpstream creates the targets of an upstream with a bounded number of requests at once. The created
+// targets are returned in the order of the requests, with nil for the targets that could not be created, and the
+// error is a *BulkError.
+func (targetClient *targetClient) CreateManyFromUpstream(upstreamNameOrId string, targetRequests []*TargetRequest, options *BulkOptions) ([]*Target, error) {
+	targets := make([]*Target, len(targetRequests))
+	err := runBulk(targetClient.config, len(targetRequests), options, func(i int) error {
+		var err error
+		targets[i], err = targetClient.CreateFromUpstreamId(upstreamNameOrId, targetRequests[i])
+		return err
+	})
+	return targets, err
+}
 
-// TODO: Implement other methods available by /targets paths
-// https://docs.konghq.com/1.0.x/admin-api/#update-or-create-upstream
-// https://docs.konghq.com/1.0.x/admin-api/#delete-upstream
+// DeleteManyFromUpstream deletes the targets of an upstream with a bounded number of requests at once, the error is
+// a *BulkError.
+func (targetClient *targetClient) DeleteManyFromUpstream(upstreamNameOrId string, hostPortsOrIds []string, options *BulkOptions) error {
+	return runBulk(targetClient.config, len(hostPortsOrIds), options, func(i int) error {
+		return targetClient.DeleteFromUpstreamById(upstreamNameOrId, hostPortsOrIds[i])
+	})
+}
@@ -3,6 +3,7 @@
 package gokong
 
 import (
+	"fmt"
 	"testing"
 	// "time"
 
@@ -141,6 +142,134 @@ func TestTargets_Delete(t *testing.T) {
 	client.Upstreams().DeleteById(createdUpstream.Id)
 }
 
+func TestTargets_ListFromUpstream(t *testing.T) {
+	upstreamRequest := &UpstreamRequest{
+		Name:  "upstream-" + uuid.NewV4().String(),
+		Slots: 10,
+	}
+
+	client := NewClient(NewDefaultConfig())
+	createdUpstream, err := client.Upstreams().Create(upstreamRequest)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, createdUpstream)
+
+	for i := 0; i < 105; i++ {
+		_, err := client.Targets().CreateFromUpstreamId(createdUpstream.Id, &TargetRequest{
+			Target: fmt.Sprintf("10.0.0.%d:80", i),
+			Weight: 100,
+		})
+		assert.Nil(t, err)
+	}
+
+	result, err := client.Targets().ListFromUpstream(createdUpstream.Name, &TargetQueryString{})
+
+	assert.Nil(t, err)
+	assert.Len(t, result, 105)
+
+	client.Upstreams().DeleteById(createdUpstream.Id)
+}
+
+func TestTargets_GetFromUpstreamByHostPortAndId(t *testing.T) {
+	upstreamRequest := &UpstreamRequest{
+		Name:  "upstream-" + uuid.NewV4().String(),
+		Slots: 10,
+	}
+
+	client := NewClient(NewDefaultConfig())
+	createdUpstream, err := client.Upstreams().Create(upstreamRequest)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, createdUpstream)
+
+	createdTarget, err := client.Targets().CreateFromUpstreamId(createdUpstream.Id, &TargetRequest{
+		Target: "www.example.com:80",
+		Weight: 200,
+	})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, createdTarget)
+
+	result, err := client.Targets().GetFromUpstreamByHostPort(createdUpstream.Name, "www.example.com:80")
+
+	assert.Nil(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, createdTarget.Id, result.Id)
+
+	result, err = client.Targets().GetFromUpstreamById(createdUpstream.Id, *createdTarget.Id)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, createdTarget.Target, result.Target)
+
+	result, err = client.Targets().GetFromUpstreamByHostPort(createdUpstream.Name, "www.example.org:80")
+
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+
+	client.Targets().DeleteFromUpstreamById(createdUpstream.Id, *createdTarget.Id)
+	client.Upstreams().DeleteById(createdUpstream.Id)
+}
+
+func TestTargets_GetAllTargetsFromUpstreamIncludesHistoricalTargets(t *testing.T) {
+	// kong 2.2 deletes targets instead of adding an entry with a weight of 0
+	kongTestContext.SkipUnlessVersion(t, "< 2.2")
+
+	upstreamRequest := &UpstreamRequest{
+		Name:  "upstream-" + uuid.NewV4().String(),
+		Slots: 10,
+	}
+
+	client := NewClient(NewDefaultConfig())
+	createdUpstream, err := client.Upstreams().Create(upstreamRequest)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, createdUpstream)
+
+	targetRequest := &TargetRequest{
+		Target: "www.example.com:80",
+		Weight: 200,
+	}
+	createdTarget, err := client.Targets().CreateFromUpstreamId(createdUpstream.Id, targetRequest)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, createdTarget)
+
+	client.Targets().DeleteFromUpstreamByHostPort(createdUpstream.Name, *createdTarget.Target)
+
+	result, err := client.Targets().GetAllTargetsFromUpstreamName(createdUpstream.Name)
+
+	assert.Nil(t, err)
+	assert.Len(t, result, 2)
+	for _, target := range result {
+		assert.Equal(t, createdUpstream.Id, IdToString(target.Upstream))
+	}
+
+	client.Upstreams().DeleteById(createdUpstream.Id)
+}
+
+func TestTargets_AllEndpointsShouldReturnErrorWhenRequestUnauthorised(t *testing.T) {
+
+	unauthorisedClient := NewClient(&Config{HostAddress: kong401Server})
+
+	targets, err := unauthorisedClient.Targets().ListFromUpstream("foo", &TargetQueryString{})
+	assert.NotNil(t, err)
+	assert.Nil(t, targets)
+
+	targets, err = unauthorisedClient.Targets().GetAllTargetsFromUpstreamName("foo")
+	assert.NotNil(t, err)
+	assert.Nil(t, targets)
+
+	target, err := unauthorisedClient.Targets().GetFromUpstreamByHostPort("foo", "bar:80")
+	assert.NotNil(t, err)
+	assert.Nil(t, target)
+
+	target, err = unauthorisedClient.Targets().UpdateFromUpstreamByHostPort("foo", "bar:80", &TargetRequest{Target: "bar:80", Weight: 10})
+	assert.NotNil(t, err)
+	assert.Nil(t, target)
+
+}
+
 // WWOM: The following test runs locally without issue and without need for hack contained therein
 // However, on the build server there seems to be a timing issue of sorts whereby trhe Kong container
 // hasn't completed the registration of a target and/or related health checks when we attempt to
@@ -156,15 +285,15 @@ func TestTargets_Delete(t *testing.T) {
 // 				Timeout:     1,
 // 				Healthy: &ActiveHealthy{
 // 					HttpStatuses: []int{200, 302},
-// 					Interval:     1000,
-// 					Successes:    10,
+// 					Interval:     Int(1000),
+// 					Successes:    Int(10),
 // 				},
 // 				Unhealthy: &ActiveUnhealthy{
-// 					HttpFailures: 10,
+// 					HttpFailures: Int(10),
 // 					HttpStatuses: []int{429, 404, 500, 501, 502, 503, 504, 505},
-// 					Interval:     1000,
-// 					TcpFailures:  10,
-// 					Timeouts:     10,
+// 					Interval:     Int(1000),
+// 					TcpFailures:  Int(10),
+// 					Timeouts:     Int(10),
 // 				},
 // 			},
 // 		},
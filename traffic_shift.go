@@ -0,0 +1,308 @@
+package gokong
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+var ErrTrafficShiftAborted = errors.New("traffic shift aborted")
+
+type TrafficShift struct {
+	Upstream         string
+	Weights          map[string]int
+	Steps            int
+	Interval         time.Duration
+	AbortOnUnhealthy bool
+}
+
+type TrafficShiftStep struct {
+	Weights map[string]int
+}
+
+type TrafficShifter struct {
+	client   KongAdminClient
+	shift    *TrafficShift
+	mutex    sync.Mutex
+	initial  map[string]int
+	steps    []*TrafficShiftStep
+	applied  int
+	resumeCh chan struct{}
+	abortCh  chan struct{}
+	aborted  bool
+}
+
+// NewTrafficShifter creates a shifter that moves the weights of the targets of an upstream towards the
+// weights in the given shift. Targets of the upstream not present in the shift keep their current weight.
+func NewTrafficShifter(client KongAdminClient, shift *TrafficShift) *TrafficShifter {
+	return &TrafficShifter{
+		client:  client,
+		shift:   shift,
+		abortCh: make(chan struct{}),
+	}
+}
+
+// Plan reads the current target weights of the upstream and computes the steps needed to reach the desired weights.
+func (shifter *TrafficShifter) Plan() ([]*TrafficShiftStep, error) {
+	shifter.mutex.Lock()
+	defer shifter.mutex.Unlock()
+
+	if shifter.steps != nil {
+		return shifter.steps, nil
+	}
+
+	if shifter.shift.Upstream == "" {
+		return nil, errors.New("traffic shift requires an upstream")
+	}
+
+	if len(shifter.shift.Weights) == 0 {
+		return nil, errors.New("traffic shift requires at least one target weight")
+	}
+
+	for target, weight := range shifter.shift.Weights {
+		if weight < 0 || weight > 1000 {
+			return nil, fmt.Errorf("invalid weight %d for target %s, weight must be between 0 and 1000", weight, target)
+		}
+	}
+
+	targets, err := shifter.client.Targets().GetTargetsFromUpstreamName(shifter.shift.Upstream)
+	if err != nil {
+		return nil, err
+	}
+
+	initial := make(map[string]int)
+	for _, target := range targets {
+		if target.Target != nil && target.Weight != nil {
+			initial[*target.Target] = *target.Weight
+		}
+	}
+
+	shifter.initial = initial
+	shifter.steps = computeTrafficShiftSteps(initial, shifter.shift.Weights, shifter.shift.Steps)
+
+	return shifter.steps, nil
+}
+
+// Run applies each planned step in turn, waiting the shift interval between steps, until all steps are applied,
+// the shift is aborted or the context is cancelled. If AbortOnUnhealthy is set and the upstream health endpoint
+// reports an unhealthy target after a step, the shift is rolled back and an error returned.
+func (shifter *TrafficShifter) Run(ctx context.Context) error {
+	steps, err := shifter.Plan()
+	if err != nil {
+		return err
+	}
+
+	for {
+		if err := shifter.waitWhilePaused(ctx); err != nil {
+			return err
+		}
+
+		shifter.mutex.Lock()
+		next := shifter.applied
+		shifter.mutex.Unlock()
+
+		if next >= len(steps) {
+			return nil
+		}
+
+		if err := shifter.apply(steps[next].Weights); err != nil {
+			return err
+		}
+
+		shifter.mutex.Lock()
+		shifter.applied = next + 1
+		shifter.mutex.Unlock()
+
+		if err := shifter.checkHealth(); err != nil {
+			if rollbackErr := shifter.Rollback(); rollbackErr != nil {
+				return fmt.Errorf("%v, rollback failed: %v", err, rollbackErr)
+			}
+			return err
+		}
+
+		if next+1 < len(steps) && shifter.shift.Interval > 0 {
+			timer := time.NewTimer(shifter.shift.Interval)
+			select {
+			case <-timer.C:
+			case <-shifter.abortCh:
+				timer.Stop()
+				return ErrTrafficShiftAborted
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+// Pause stops Run from applying further steps until Resume is called.
+func (shifter *TrafficShifter) Pause() {
+	shifter.mutex.Lock()
+	defer shifter.mutex.Unlock()
+
+	if shifter.resumeCh == nil {
+		shifter.resumeCh = make(chan struct{})
+	}
+}
+
+func (shifter *TrafficShifter) Resume() {
+	shifter.mutex.Lock()
+	defer shifter.mutex.Unlock()
+
+	if shifter.resumeCh != nil {
+		close(shifter.resumeCh)
+		shifter.resumeCh = nil
+	}
+}
+
+// Abort stops Run without changing the weights already applied.
+func (shifter *TrafficShifter) Abort() {
+	shifter.mutex.Lock()
+	defer shifter.mutex.Unlock()
+
+	if !shifter.aborted {
+		shifter.aborted = true
+		close(shifter.abortCh)
+	}
+}
+
+// Rollback restores the weights the targets had when the shift was planned. Targets that did not exist before
+// the shift are removed from the upstream.
+func (shifter *TrafficShifter) Rollback() error {
+	shifter.mutex.Lock()
+	initial := shifter.initial
+	applied := shifter.applied
+	shifter.mutex.Unlock()
+
+	if initial == nil || applied == 0 {
+		return nil
+	}
+
+	for _, target := range sortedTargets(shifter.shift.Weights) {
+		weight, existed := initial[target]
+		if !existed {
+			err := shifter.client.Targets().DeleteFromUpstreamByHostPort(shifter.shift.Upstream, target)
+			if err != nil {
+				return fmt.Errorf("could not remove target %s, error: %v", target, err)
+			}
+			continue
+		}
+
+		_, err := shifter.client.Targets().CreateFromUpstreamName(shifter.shift.Upstream, &TargetRequest{Target: target, Weight: weight})
+		if err != nil {
+			return fmt.Errorf("could not restore weight of target %s, error: %v", target, err)
+		}
+	}
+
+	shifter.mutex.Lock()
+	shifter.applied = 0
+	shifter.mutex.Unlock()
+
+	return nil
+}
+
+// AppliedSteps returns the number of planned steps that have been applied to the upstream.
+func (shifter *TrafficShifter) AppliedSteps() int {
+	shifter.mutex.Lock()
+	defer shifter.mutex.Unlock()
+
+	return shifter.applied
+}
+
+func (shifter *TrafficShifter) waitWhilePaused(ctx context.Context) error {
+	for {
+		shifter.mutex.Lock()
+		resumeCh := shifter.resumeCh
+		shifter.mutex.Unlock()
+
+		select {
+		case <-shifter.abortCh:
+			return ErrTrafficShiftAborted
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if resumeCh == nil {
+			return nil
+		}
+
+		select {
+		case <-resumeCh:
+		case <-shifter.abortCh:
+			return ErrTrafficShiftAborted
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (shifter *TrafficShifter) apply(weights map[string]int) error {
+	for _, target := range sortedTargets(weights) {
+		_, err := shifter.client.Targets().CreateFromUpstreamName(shifter.shift.Upstream, &TargetRequest{Target: target, Weight: weights[target]})
+		if err != nil {
+			return fmt.Errorf("could not set weight of target %s, error: %v", target, err)
+		}
+	}
+
+	return nil
+}
+
+func (shifter *TrafficShifter) checkHealth() error {
+	if !shifter.shift.AbortOnUnhealthy {
+		return nil
+	}
+
+	upstreamHealth, err := shifter.client.Upstreams().GetHealthByName(shifter.shift.Upstream)
+	if err != nil {
+		return fmt.Errorf("could not get upstream health, error: %v", err)
+	}
+
+	if upstreamHealth == nil {
+		return fmt.Errorf("non existent upstream: %s", shifter.shift.Upstream)
+	}
+
+	for _, target := range upstreamHealth.Targets {
+		if target.Health != nil && *target.Health == "UNHEALTHY" && target.Weight != nil && *target.Weight > 0 {
+			name := "with no address"
+			if target.Target != nil {
+				name = *target.Target
+			} else if target.Id != nil {
+				name = *target.Id
+			}
+			return fmt.Errorf("target %s of upstream %s is unhealthy", name, shifter.shift.Upstream)
+		}
+	}
+
+	return nil
+}
+
+func computeTrafficShiftSteps(initial map[string]int, desired map[string]int, steps int) []*TrafficShiftStep {
+	if steps < 1 {
+		steps = 1
+	}
+
+	result := make([]*TrafficShiftStep, 0, steps)
+	for i := 1; i <= steps; i++ {
+		weights := make(map[string]int, len(desired))
+		for target, to := range desired {
+			from := initial[target]
+			weights[target] = from + (to-from)*i/steps
+		}
+		result = append(result, &TrafficShiftStep{Weights: weights})
+	}
+
+	return result
+}
+
+func sortedTargets(weights map[string]int) []string {
+	targets := make([]string, 0, len(weights))
+	for target := range weights {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+	return targets
+}
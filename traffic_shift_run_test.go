@@ -0,0 +1,139 @@
+package gokong_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/globocom/gokong"
+	"github.com/globocom/gokong/gokongtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newShiftServer starts a fake kong with an upstream whose blue:80 target has all the traffic.
+func newShiftServer(t *testing.T) (*gokongtest.Server, gokong.KongAdminClient) {
+	server := gokongtest.NewServer()
+	client := gokong.NewClient(&gokong.Config{HostAddress: server.URL})
+
+	_, err := client.Upstreams().Create(&gokong.UpstreamRequest{Name: "upstream"})
+	assert.Nil(t, err)
+	_, err = client.Targets().CreateFromUpstreamName("upstream", &gokong.TargetRequest{Target: "blue:80", Weight: 100})
+	assert.Nil(t, err)
+
+	return server, client
+}
+
+func blueGreenShift(steps int, interval time.Duration) *gokong.TrafficShift {
+	return &gokong.TrafficShift{
+		Upstream: "upstream",
+		Weights:  map[string]int{"blue:80": 0, "green:80": 100},
+		Steps:    steps,
+		Interval: interval,
+	}
+}
+
+// targetWeights returns the weights of the targets of the upstream that receive traffic.
+func targetWeights(t *testing.T, client gokong.KongAdminClient) map[string]int {
+	targets, err := client.Targets().GetTargetsFromUpstreamName("upstream")
+	assert.Nil(t, err)
+
+	weights := make(map[string]int)
+	for _, target := range targets {
+		if *target.Weight > 0 {
+			weights[*target.Target] = *target.Weight
+		}
+	}
+	return weights
+}
+
+func runInBackground(shifter *gokong.TrafficShifter) <-chan error {
+	done := make(chan error, 1)
+	go func() {
+		done <- shifter.Run(context.Background())
+	}()
+	return done
+}
+
+func waitForRun(t *testing.T, done <-chan error) error {
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(time.Second):
+		t.Fatal("traffic shift did not stop")
+		return nil
+	}
+}
+
+func TestTrafficShifter_RunAppliesEveryStep(t *testing.T) {
+	server, client := newShiftServer(t)
+	defer server.Close()
+	shifter := gokong.NewTrafficShifter(client, blueGreenShift(4, time.Millisecond))
+
+	err := shifter.Run(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, 4, shifter.AppliedSteps())
+	assert.Equal(t, map[string]int{"green:80": 100}, targetWeights(t, client))
+}
+
+func TestTrafficShifter_PauseAndResume(t *testing.T) {
+	server, client := newShiftServer(t)
+	defer server.Close()
+	shifter := gokong.NewTrafficShifter(client, blueGreenShift(2, time.Millisecond))
+
+	shifter.Pause()
+	done := runInBackground(shifter)
+	time.Sleep(20 * time.Millisecond)
+
+	assert.Equal(t, 0, shifter.AppliedSteps())
+	assert.Equal(t, map[string]int{"blue:80": 100}, targetWeights(t, client))
+
+	shifter.Resume()
+
+	assert.Nil(t, waitForRun(t, done))
+	assert.Equal(t, 2, shifter.AppliedSteps())
+	assert.Equal(t, map[string]int{"green:80": 100}, targetWeights(t, client))
+}
+
+func TestTrafficShifter_AbortKeepsAppliedWeights(t *testing.T) {
+	server, client := newShiftServer(t)
+	defer server.Close()
+	shifter := gokong.NewTrafficShifter(client, blueGreenShift(2, time.Minute))
+
+	done := runInBackground(shifter)
+	assert.Eventually(t, func() bool { return shifter.AppliedSteps() == 1 }, time.Second, time.Millisecond)
+	shifter.Abort()
+
+	assert.Equal(t, gokong.ErrTrafficShiftAborted, waitForRun(t, done))
+	assert.Equal(t, 1, shifter.AppliedSteps())
+	assert.Equal(t, map[string]int{"blue:80": 50, "green:80": 50}, targetWeights(t, client))
+}
+
+func TestTrafficShifter_RollbackRestoresInitialWeights(t *testing.T) {
+	server, client := newShiftServer(t)
+	defer server.Close()
+	shifter := gokong.NewTrafficShifter(client, blueGreenShift(2, 0))
+	assert.Nil(t, shifter.Run(context.Background()))
+
+	err := shifter.Rollback()
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, shifter.AppliedSteps())
+	assert.Equal(t, map[string]int{"blue:80": 100}, targetWeights(t, client))
+}
+
+func TestTrafficShifter_RollsBackWhenATargetIsUnhealthy(t *testing.T) {
+	server, client := newShiftServer(t)
+	defer server.Close()
+	assert.Nil(t, client.Targets().SetTargetFromUpstreamByHostPortAsUnhealthy("upstream", "blue:80"))
+	shift := blueGreenShift(2, 0)
+	shift.AbortOnUnhealthy = true
+	shifter := gokong.NewTrafficShifter(client, shift)
+
+	err := shifter.Run(context.Background())
+
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "target blue:80 of upstream upstream is unhealthy")
+	assert.Equal(t, 0, shifter.AppliedSteps())
+	assert.Equal(t, map[string]int{"blue:80": 100}, targetWeights(t, client))
+}
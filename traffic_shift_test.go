@@ -0,0 +1,59 @@
+package gokong
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrafficShift_ComputeStepsMovesWeightLinearly(t *testing.T) {
+	initial := map[string]int{"blue:80": 100}
+	desired := map[string]int{"blue:80": 0, "green:80": 100}
+
+	steps := computeTrafficShiftSteps(initial, desired, 4)
+
+	assert.Len(t, steps, 4)
+	assert.Equal(t, map[string]int{"blue:80": 75, "green:80": 25}, steps[0].Weights)
+	assert.Equal(t, map[string]int{"blue:80": 50, "green:80": 50}, steps[1].Weights)
+	assert.Equal(t, map[string]int{"blue:80": 25, "green:80": 75}, steps[2].Weights)
+	assert.Equal(t, map[string]int{"blue:80": 0, "green:80": 100}, steps[3].Weights)
+}
+
+func TestTrafficShift_ComputeStepsEndsOnDesiredWeights(t *testing.T) {
+	initial := map[string]int{"blue:80": 100, "green:80": 10}
+	desired := map[string]int{"blue:80": 33, "green:80": 67}
+
+	steps := computeTrafficShiftSteps(initial, desired, 7)
+
+	assert.Len(t, steps, 7)
+	assert.Equal(t, desired, steps[6].Weights)
+}
+
+func TestTrafficShift_ComputeStepsDefaultsToSingleStep(t *testing.T) {
+	steps := computeTrafficShiftSteps(map[string]int{}, map[string]int{"green:80": 100}, 0)
+
+	assert.Len(t, steps, 1)
+	assert.Equal(t, map[string]int{"green:80": 100}, steps[0].Weights)
+}
+
+func TestTrafficShift_CheckHealthReportsTargetsWithoutAddress(t *testing.T) {
+	client := NewClient(&Config{
+		HostAddress: "http://kong:8001",
+		HTTPClient: &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			body := `{"data": [{"id": "6d1b6b5e-6a3d-4b9a-8d3e-0c0b5a4d6f1e", "weight": 100, "health": "UNHEALTHY"}], "next": null}`
+			if req.URL.Query().Get("balancer_health") != "" {
+				body = `{"data": {"id": "upstream", "health": "HEALTHY"}}`
+			}
+			return &http.Response{StatusCode: 200, Header: http.Header{}, Body: ioutil.NopCloser(bytes.NewBufferString(body))}, nil
+		})},
+	})
+	shifter := NewTrafficShifter(client, &TrafficShift{Upstream: "upstream", AbortOnUnhealthy: true})
+
+	err := shifter.checkHealth()
+
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "target 6d1b6b5e-6a3d-4b9a-8d3e-0c0b5a4d6f1e of upstream upstream is unhealthy")
+}
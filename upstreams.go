@@ -12,8 +12,13 @@ type UpstreamClient interface {
 	DeleteByName(name string) error
 	DeleteById(id string) error
 	List() (*Upstreams, error)
+	ListWithQuery(query *UpstreamQueryString) ([]*Upstream, error)
 	UpdateByName(name string, upstreamRequest *UpstreamRequest) (*Upstream, error)
 	UpdateById(id string, upstreamRequest *UpstreamRequest) (*Upstream, error)
+	GetHealthByName(name string) (*UpstreamHealth, error)
+	GetHealthById(id string) (*UpstreamHealth, error)
+	SetAddressAsHealthy(upstreamNameOrId string, target string, address string) error
+	SetAddressAsUnhealthy(upstreamNameOrId string, target string, address string) error
 }
 
 type upstreamClient struct {
@@ -21,16 +26,23 @@ type upstreamClient struct {
 }
 
 type UpstreamRequest struct {
-	Name               string               `json:"name" yaml:"name"`
-	Slots              int                  `json:"slots,omitempty" yaml:"slots,omitempty"`
-	HashOn             string               `json:"hash_on,omitempty" yaml:"hash_on,omitempty"`
-	HashFallback       string               `json:"hash_fallback,omitempty" yaml:"hash_fallback,omitempty"`
-	HashOnHeader       string               `json:"hash_on_header,omitempty" yaml:"hash_on_header,omitempty"`
-	HashFallbackHeader string               `json:"hash_fallback_header,omitempty" yaml:"hash_fallback_header,omitempty"`
-	HashOnCookie       string               `json:"hash_on_cookie,omitempty" yaml:"hash_on_cookie,omitempty"`
-	HashOnCookiePath   string               `json:"hash_on_cookie_path,omitempty" yaml:"hash_on_cookie_path,omitempty"`
-	HealthChecks       *UpstreamHealthCheck `json:"healthchecks,omitempty" yaml:"healthchecks,omitempty"`
-	Tags               []*string            `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Name                   string               `json:"name" yaml:"name"`
+	Algorithm              string               `json:"algorithm,omitempty" yaml:"algorithm,omitempty"`
+	Slots                  int                  `json:"slots,omitempty" yaml:"slots,omitempty"`
+	HashOn                 string               `json:"hash_on,omitempty" yaml:"hash_on,omitempty"`
+	HashFallback           string               `json:"hash_fallback,omitempty" yaml:"hash_fallback,omitempty"`
+	HashOnHeader           string               `json:"hash_on_header,omitempty" yaml:"hash_on_header,omitempty"`
+	HashFallbackHeader     string               `json:"hash_fallback_header,omitempty" yaml:"hash_fallback_header,omitempty"`
+	HashOnCookie           string               `json:"hash_on_cookie,omitempty" yaml:"hash_on_cookie,omitempty"`
+	HashOnCookiePath       string               `json:"hash_on_cookie_path,omitempty" yaml:"hash_on_cookie_path,omitempty"`
+	HashOnQueryArg         string               `json:"hash_on_query_arg,omitempty" yaml:"hash_on_query_arg,omitempty"`
+	HashFallbackQueryArg   string               `json:"hash_fallback_query_arg,omitempty" yaml:"hash_fallback_query_arg,omitempty"`
+	HashOnUriCapture       string               `json:"hash_on_uri_capture,omitempty" yaml:"hash_on_uri_capture,omitempty"`
+	HashFallbackUriCapture string               `json:"hash_fallback_uri_capture,omitempty" yaml:"hash_fallback_uri_capture,omitempty"`
+	HostHeader             *string              `json:"host_header,omitempty" yaml:"host_header,omitempty"`
+	ClientCertificate      *Id                  `json:"client_certificate,omitempty" yaml:"client_certificate,omitempty"`
+	HealthChecks           *UpstreamHealthCheck `json:"healthchecks,omitempty" yaml:"healthchecks,omitempty"`
+	Tags                   []*string            `json:"tags,omitempty" yaml:"tags,omitempty"`
 }
 
 type UpstreamHealthCheck struct {
@@ -39,28 +51,29 @@ type UpstreamHealthCheck struct {
 }
 
 type UpstreamHealthCheckActive struct {
-	Type                   string           `json:"type,omitempty" yaml:"type,omitempty"`
-	Concurrency            int              `json:"concurrency,omitempty" yaml:"concurrency,omitempty"`
-	Healthy                *ActiveHealthy   `json:"healthy,omitempty" yaml:"healthy,omitempty"`
-	HttpPath               string           `json:"http_path,omitempty" yaml:"http_path,omitempty"`
-	HttpsVerifyCertificate bool             `json:"https_verify_certificate" yaml:"https_verify_certificate"`
-	HttpsSni               *string          `json:"https_sni,omitempty" yaml:"https_sni,omitempty"`
-	Timeout                int              `json:"timeout,omitempty" yaml:"timeout,omitempty"`
-	Unhealthy              *ActiveUnhealthy `json:"unhealthy,omitempty" yaml:"unhealthy,omitempty"`
+	Type                   string              `json:"type,omitempty" yaml:"type,omitempty"`
+	Concurrency            int                 `json:"concurrency,omitempty" yaml:"concurrency,omitempty"`
+	Healthy                *ActiveHealthy      `json:"healthy,omitempty" yaml:"healthy,omitempty"`
+	Headers                map[string][]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+	HttpPath               string              `json:"http_path,omitempty" yaml:"http_path,omitempty"`
+	HttpsVerifyCertificate *bool               `json:"https_verify_certificate,omitempty" yaml:"https_verify_certificate,omitempty"`
+	HttpsSni               *string             `json:"https_sni,omitempty" yaml:"https_sni,omitempty"`
+	Timeout                int                 `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	Unhealthy              *ActiveUnhealthy    `json:"unhealthy,omitempty" yaml:"unhealthy,omitempty"`
 }
 
 type ActiveHealthy struct {
 	HttpStatuses []int `json:"http_statuses,omitempty" yaml:"http_statuses,omitempty"`
-	Interval     int   `json:"interval" yaml:"interval"`
-	Successes    int   `json:"successes" yaml:"successes"`
+	Interval     *int  `json:"interval,omitempty" yaml:"interval,omitempty"`
+	Successes    *int  `json:"successes,omitempty" yaml:"successes,omitempty"`
 }
 
 type ActiveUnhealthy struct {
-	HttpFailures int   `json:"http_failures" yaml:"http_failures"`
+	HttpFailures *int  `json:"http_failures,omitempty" yaml:"http_failures,omitempty"`
 	HttpStatuses []int `json:"http_statuses,omitempty" yaml:"http_statuses,omitempty"`
-	Interval     int   `json:"interval" yaml:"interval"`
-	TcpFailures  int   `json:"tcp_failures" yaml:"tcp_failures"`
-	Timeouts     int   `json:"timeouts" yaml:"timeouts"`
+	Interval     *int  `json:"interval,omitempty" yaml:"interval,omitempty"`
+	TcpFailures  *int  `json:"tcp_failures,omitempty" yaml:"tcp_failures,omitempty"`
+	Timeouts     *int  `json:"timeouts,omitempty" yaml:"timeouts,omitempty"`
 }
 
 type UpstreamHealthCheckPassive struct {
@@ -71,14 +84,14 @@ type UpstreamHealthCheckPassive struct {
 
 type PassiveHealthy struct {
 	HttpStatuses []int `json:"http_statuses,omitempty" yaml:"http_statuses,omitempty"`
-	Successes    int   `json:"successes" yaml:"successes"`
+	Successes    *int  `json:"successes,omitempty" yaml:"successes,omitempty"`
 }
 
 type PassiveUnhealthy struct {
-	HttpFailures int   `json:"http_failures" yaml:"http_failures"`
+	HttpFailures *int  `json:"http_failures,omitempty" yaml:"http_failures,omitempty"`
 	HttpStatuses []int `json:"http_statuses,omitempty" yaml:"http_statuses,omitempty"`
-	TcpFailures  int   `json:"tcp_failures" yaml:"tcp_failures"`
-	Timeouts     int   `json:"timeouts" yaml:"timeouts"`
+	TcpFailures  *int  `json:"tcp_failures,omitempty" yaml:"tcp_failures,omitempty"`
+	Timeouts     *int  `json:"timeouts,omitempty" yaml:"timeouts,omitempty"`
 }
 
 type Upstream struct {
@@ -90,6 +103,62 @@ type Upstream struct {
 type Upstreams struct {
 	Results []*Upstream `json:"data,omitempty" yaml:"data,omitempty"`
 	Next    string      `json:"next,omitempty" yaml:"next,omitempty"`
+	Offset  string      `json:"offset,omitempty" yaml:"offset,omitempty"`
+}
+
+type UpstreamQueryString struct {
+	Offset string `json:"offset,omitempty"`
+	Size   int    `json:"size"`
+}
+
+type UpstreamHealth struct {
+	Id      string          `json:"id" yaml:"id"`
+	Health  string          `json:"health" yaml:"health"`
+	Targets []*TargetHealth `json:"targets,omitempty" yaml:"targets,omitempty"`
+}
+
+type TargetHealth struct {
+	Id        *string           `json:"id,omitempty" yaml:"id,omitempty"`
+	CreatedAt *float32          `json:"created_at" yaml:"created_at"`
+	Target    *string           `json:"target" yaml:"target"`
+	Weight    *int              `json:"weight" yaml:"weight"`
+	Health    *string           `json:"health" yaml:"health"`
+	Data      *TargetHealthData `json:"data,omitempty" yaml:"data,omitempty"`
+}
+
+type TargetHealthData struct {
+	Addresses  []*AddressHealth    `json:"addresses,omitempty" yaml:"addresses,omitempty"`
+	Dns        string              `json:"dns,omitempty" yaml:"dns,omitempty"`
+	NodeWeight int                 `json:"nodeWeight,omitempty" yaml:"nodeWeight,omitempty"`
+	Weight     *TargetHealthWeight `json:"weight,omitempty" yaml:"weight,omitempty"`
+}
+
+type AddressHealth struct {
+	Ip     string `json:"ip" yaml:"ip"`
+	Port   int    `json:"port" yaml:"port"`
+	Health string `json:"health" yaml:"health"`
+	Weight int    `json:"weight" yaml:"weight"`
+}
+
+type TargetHealthWeight struct {
+	Available   int `json:"available" yaml:"available"`
+	Total       int `json:"total" yaml:"total"`
+	Unavailable int `json:"unavailable" yaml:"unavailable"`
+}
+
+type upstreamBalancerHealth struct {
+	Data *UpstreamHealth `json:"data" yaml:"data"`
+}
+
+type upstreamTargetsHealth struct {
+	Data   []*TargetHealth `json:"data" yaml:"data"`
+	Next   *string         `json:"next" yaml:"next"`
+	Offset string          `json:"offset,omitempty" yaml:"offset,omitempty"`
+}
+
+type upstreamHealthQueryString struct {
+	BalancerHealth int    `json:"balancer_health,omitempty"`
+	Offset         string `json:"offset,omitempty"`
 }
 
 const UpstreamsPath = "/upstreams/"
@@ -180,6 +249,53 @@ func (upstreamClient *upstreamClient) List() (*Upstreams, error) {
 	return upstreams, nil
 }
 
+// ListWithQuery returns all the upstreams, following the pages of the list from query.Offset. A nil query lists
+// from the first page.
+func (upstreamClient *upstreamClient) ListWithQuery(query *UpstreamQueryString) ([]*Upstream, error) {
+	upstreams := make([]*Upstream, 0)
+
+	pageQuery := UpstreamQueryString{}
+	if query != nil {
+		pageQuery = *query
+	}
+
+	if pageQuery.Size < 100 {
+		pageQuery.Size = 100
+	}
+
+	if pageQuery.Size > 1000 {
+		pageQuery.Size = 1000
+	}
+
+	for {
+		data := &Upstreams{}
+
+		r, body, errs := newGet(upstreamClient.config, UpstreamsPath).Query(pageQuery).End()
+		if errs != nil {
+			return nil, fmt.Errorf("could not get upstreams, error: %v", errs)
+		}
+
+		if r.StatusCode == 401 || r.StatusCode == 403 {
+			return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+		}
+
+		err := json.Unmarshal([]byte(body), data)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse upstreams list response, error: %v", err)
+		}
+
+		upstreams = append(upstreams, data.Results...)
+
+		if data.Next == "" || data.Offset == "" {
+			break
+		}
+
+		pageQuery.Offset = data.Offset
+	}
+
+	return upstreams, nil
+}
+
 func (upstreamClient *upstreamClient) UpdateByName(name string, upstreamRequest *UpstreamRequest) (*Upstream, error) {
 	return upstreamClient.UpdateById(name, upstreamRequest)
 }
@@ -206,3 +322,90 @@ func (upstreamClient *upstreamClient) UpdateById(id string, upstreamRequest *Ups
 
 	return updatedUpstream, nil
 }
+
+func (upstreamClient *upstreamClient) GetHealthByName(name string) (*UpstreamHealth, error) {
+	return upstreamClient.GetHealthById(name)
+}
+
+func (upstreamClient *upstreamClient) GetHealthById(id string) (*UpstreamHealth, error) {
+	r, body, errs := newGet(upstreamClient.config, UpstreamsPath+id+"/health").Query(upstreamHealthQueryString{BalancerHealth: 1}).End()
+	if errs != nil {
+		return nil, fmt.Errorf("could not get upstream health, error: %v", errs)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	if r.StatusCode == 404 {
+		return nil, nil
+	}
+
+	balancerHealth := &upstreamBalancerHealth{}
+	err := json.Unmarshal([]byte(body), balancerHealth)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse upstream health response, error: %v", err)
+	}
+
+	if balancerHealth.Data == nil || balancerHealth.Data.Id == "" {
+		return nil, fmt.Errorf("could not get upstream health, error: %v", body)
+	}
+
+	upstreamHealth := balancerHealth.Data
+	upstreamHealth.Targets = make([]*TargetHealth, 0)
+	query := upstreamHealthQueryString{}
+
+	for {
+		data := &upstreamTargetsHealth{}
+
+		r, body, errs := newGet(upstreamClient.config, UpstreamsPath+id+"/health").Query(query).End()
+		if errs != nil {
+			return nil, fmt.Errorf("could not get upstream targets health, error: %v", errs)
+		}
+
+		if r.StatusCode == 401 || r.StatusCode == 403 {
+			return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+		}
+
+		err := json.Unmarshal([]byte(body), data)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse upstream targets health response, error: %v", err)
+		}
+
+		upstreamHealth.Targets = append(upstreamHealth.Targets, data.Data...)
+
+		if data.Next == nil || *data.Next == "" || data.Offset == "" {
+			break
+		}
+
+		query.Offset = data.Offset
+	}
+
+	return upstreamHealth, nil
+}
+
+func (upstreamClient *upstreamClient) SetAddressAsHealthy(upstreamNameOrId string, target string, address string) error {
+	return upstreamClient.setAddressHealth(upstreamNameOrId, target, address, "healthy")
+}
+
+func (upstreamClient *upstreamClient) SetAddressAsUnhealthy(upstreamNameOrId string, target string, address string) error {
+	return upstreamClient.setAddressHealth(upstreamNameOrId, target, address, "unhealthy")
+}
+
+func (upstreamClient *upstreamClient) setAddressHealth(upstreamNameOrId string, target string, address string, health string) error {
+	requestPath := fmt.Sprintf("%s%s/targets/%s/%s/%s", UpstreamsPath, upstreamNameOrId, target, address, health)
+	r, body, errs := newPost(upstreamClient.config, requestPath).Send("").End()
+	if errs != nil {
+		return fmt.Errorf("could not set the address as %s, result: %v error: %v", health, r, errs)
+	}
+
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	if r.StatusCode != 204 {
+		return fmt.Errorf("Received unexpected response status code: %d. Body: %s", r.StatusCode, body)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package gokong
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpstreamRequest_MarshalJSONOmitsUnsetFields(t *testing.T) {
+	upstreamRequest := &UpstreamRequest{
+		Name: "upstream",
+		HealthChecks: &UpstreamHealthCheck{
+			Active: &UpstreamHealthCheckActive{
+				HttpPath: "/status",
+			},
+		},
+	}
+
+	result, err := json.Marshal(upstreamRequest)
+
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"name":"upstream","healthchecks":{"active":{"http_path":"/status"}}}`, string(result))
+}
+
+func TestUpstreamRequest_MarshalJSONIncludesSetFields(t *testing.T) {
+	upstreamRequest := &UpstreamRequest{
+		Name:                   "upstream",
+		Algorithm:              "least-connections",
+		HashOn:                 "query_arg",
+		HashOnQueryArg:         "user",
+		HashFallback:           "uri_capture",
+		HashFallbackUriCapture: "tenant",
+		HostHeader:             String("example.com"),
+		ClientCertificate:      ToId("b2b2d4d4-0c7a-4b5c-8a2b-2f0f2f0f2f0f"),
+		HealthChecks: &UpstreamHealthCheck{
+			Active: &UpstreamHealthCheckActive{
+				Headers:                map[string][]string{"X-Health": {"1"}},
+				HttpsVerifyCertificate: Bool(false),
+			},
+		},
+	}
+
+	result, err := json.Marshal(upstreamRequest)
+
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"name":"upstream",
+		"algorithm":"least-connections",
+		"hash_on":"query_arg",
+		"hash_on_query_arg":"user",
+		"hash_fallback":"uri_capture",
+		"hash_fallback_uri_capture":"tenant",
+		"host_header":"example.com",
+		"client_certificate":{"id":"b2b2d4d4-0c7a-4b5c-8a2b-2f0f2f0f2f0f"},
+		"healthchecks":{"active":{"headers":{"X-Health":["1"]},"https_verify_certificate":false}}
+	}`, string(result))
+}
+
+func TestUpstreamRequest_MarshalJSONOmitsUnsetHealthCheckThresholds(t *testing.T) {
+	upstreamRequest := &UpstreamRequest{
+		Name: "upstream",
+		HealthChecks: &UpstreamHealthCheck{
+			Active: &UpstreamHealthCheckActive{
+				Healthy:   &ActiveHealthy{Interval: Int(5)},
+				Unhealthy: &ActiveUnhealthy{Interval: Int(0), TcpFailures: Int(3)},
+			},
+			Passive: &UpstreamHealthCheckPassive{
+				Unhealthy: &PassiveUnhealthy{Timeouts: Int(2)},
+			},
+		},
+	}
+
+	result, err := json.Marshal(upstreamRequest)
+
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"name":"upstream",
+		"healthchecks":{
+			"active":{"healthy":{"interval":5},"unhealthy":{"interval":0,"tcp_failures":3}},
+			"passive":{"unhealthy":{"timeouts":2}}
+		}
+	}`, string(result))
+}
@@ -79,32 +79,32 @@ func Test_UpstreamsCreate(t *testing.T) {
 				Concurrency:            10,
 				HttpPath:               "/",
 				Timeout:                1,
-				HttpsVerifyCertificate: true,
+				HttpsVerifyCertificate: Bool(true),
 				HttpsSni:               String("dome.domain"),
 				Healthy: &ActiveHealthy{
 					HttpStatuses: []int{200, 302},
-					Interval:     0,
-					Successes:    0,
+					Interval:     Int(0),
+					Successes:    Int(0),
 				},
 				Unhealthy: &ActiveUnhealthy{
-					HttpFailures: 0,
+					HttpFailures: Int(0),
 					HttpStatuses: []int{429, 404, 500, 501, 502, 503, 504, 505},
-					Interval:     0,
-					TcpFailures:  0,
-					Timeouts:     0,
+					Interval:     Int(0),
+					TcpFailures:  Int(0),
+					Timeouts:     Int(0),
 				},
 			},
 			Passive: &UpstreamHealthCheckPassive{
 				Type: "http",
 				Healthy: &PassiveHealthy{
 					HttpStatuses: []int{200, 201, 202, 203, 204, 205, 206, 207, 208, 226, 300, 301, 302, 303, 304, 305, 306, 307, 308},
-					Successes:    0,
+					Successes:    Int(0),
 				},
 				Unhealthy: &PassiveUnhealthy{
-					HttpFailures: 0,
+					HttpFailures: Int(0),
 					HttpStatuses: []int{429, 500, 503},
-					TcpFailures:  0,
-					Timeouts:     0,
+					TcpFailures:  Int(0),
+					Timeouts:     Int(0),
 				},
 			},
 		},
@@ -217,32 +217,32 @@ func Test_UpstreamsUpdateById(t *testing.T) {
 				Concurrency:            10,
 				HttpPath:               "/",
 				Timeout:                1,
-				HttpsVerifyCertificate: true,
+				HttpsVerifyCertificate: Bool(true),
 				HttpsSni:               nil,
 				Healthy: &ActiveHealthy{
 					HttpStatuses: []int{200, 302},
-					Interval:     10,
-					Successes:    10,
+					Interval:     Int(10),
+					Successes:    Int(10),
 				},
 				Unhealthy: &ActiveUnhealthy{
-					HttpFailures: 10,
+					HttpFailures: Int(10),
 					HttpStatuses: []int{429, 404, 500, 501, 502, 503, 504, 505},
-					Interval:     10,
-					TcpFailures:  10,
-					Timeouts:     10,
+					Interval:     Int(10),
+					TcpFailures:  Int(10),
+					Timeouts:     Int(10),
 				},
 			},
 			Passive: &UpstreamHealthCheckPassive{
 				Type: "http",
 				Healthy: &PassiveHealthy{
 					HttpStatuses: []int{200, 201, 202, 203, 204, 205, 206, 207, 208, 226, 300, 301, 302, 303, 304, 305, 306, 307, 308},
-					Successes:    10,
+					Successes:    Int(10),
 				},
 				Unhealthy: &PassiveUnhealthy{
-					HttpFailures: 10,
+					HttpFailures: Int(10),
 					HttpStatuses: []int{429, 500, 503},
-					TcpFailures:  10,
-					Timeouts:     10,
+					TcpFailures:  Int(10),
+					Timeouts:     Int(10),
 				},
 			},
 		},
@@ -257,17 +257,17 @@ func Test_UpstreamsUpdateById(t *testing.T) {
 	upstreamRequest.Slots = 11
 	// Turn off health checks to ensure we can update from active to inactive state
 	// "healthy" checks
-	upstreamRequest.HealthChecks.Active.Healthy.Interval = 0
-	upstreamRequest.HealthChecks.Active.Healthy.Successes = 0
-	upstreamRequest.HealthChecks.Passive.Healthy.Successes = 0
+	upstreamRequest.HealthChecks.Active.Healthy.Interval = Int(0)
+	upstreamRequest.HealthChecks.Active.Healthy.Successes = Int(0)
+	upstreamRequest.HealthChecks.Passive.Healthy.Successes = Int(0)
 	// "unhealthy" checks
-	upstreamRequest.HealthChecks.Active.Unhealthy.Interval = 0
-	upstreamRequest.HealthChecks.Active.Unhealthy.HttpFailures = 0
-	upstreamRequest.HealthChecks.Active.Unhealthy.TcpFailures = 0
-	upstreamRequest.HealthChecks.Active.Unhealthy.Timeouts = 0
-	upstreamRequest.HealthChecks.Passive.Unhealthy.HttpFailures = 0
-	upstreamRequest.HealthChecks.Passive.Unhealthy.TcpFailures = 0
-	upstreamRequest.HealthChecks.Passive.Unhealthy.Timeouts = 0
+	upstreamRequest.HealthChecks.Active.Unhealthy.Interval = Int(0)
+	upstreamRequest.HealthChecks.Active.Unhealthy.HttpFailures = Int(0)
+	upstreamRequest.HealthChecks.Active.Unhealthy.TcpFailures = Int(0)
+	upstreamRequest.HealthChecks.Active.Unhealthy.Timeouts = Int(0)
+	upstreamRequest.HealthChecks.Passive.Unhealthy.HttpFailures = Int(0)
+	upstreamRequest.HealthChecks.Passive.Unhealthy.TcpFailures = Int(0)
+	upstreamRequest.HealthChecks.Passive.Unhealthy.Timeouts = Int(0)
 
 	result, err := client.Upstreams().UpdateById(createdUpstream.Id, upstreamRequest)
 
@@ -292,32 +292,32 @@ func Test_UpstreamsUpdateByName(t *testing.T) {
 				Concurrency:            10,
 				HttpPath:               "/",
 				Timeout:                1,
-				HttpsVerifyCertificate: true,
+				HttpsVerifyCertificate: Bool(true),
 				HttpsSni:               nil,
 				Healthy: &ActiveHealthy{
 					HttpStatuses: []int{200, 302},
-					Interval:     0,
-					Successes:    0,
+					Interval:     Int(0),
+					Successes:    Int(0),
 				},
 				Unhealthy: &ActiveUnhealthy{
-					HttpFailures: 0,
+					HttpFailures: Int(0),
 					HttpStatuses: []int{429, 404, 500, 501, 502, 503, 504, 505},
-					Interval:     0,
-					TcpFailures:  0,
-					Timeouts:     0,
+					Interval:     Int(0),
+					TcpFailures:  Int(0),
+					Timeouts:     Int(0),
 				},
 			},
 			Passive: &UpstreamHealthCheckPassive{
 				Type: "http",
 				Healthy: &PassiveHealthy{
 					HttpStatuses: []int{200, 201, 202, 203, 204, 205, 206, 207, 208, 226, 300, 301, 302, 303, 304, 305, 306, 307, 308},
-					Successes:    0,
+					Successes:    Int(0),
 				},
 				Unhealthy: &PassiveUnhealthy{
-					HttpFailures: 0,
+					HttpFailures: Int(0),
 					HttpStatuses: []int{429, 500, 503},
-					TcpFailures:  0,
-					Timeouts:     0,
+					TcpFailures:  Int(0),
+					Timeouts:     Int(0),
 				},
 			},
 		},
@@ -389,6 +389,70 @@ func Test_UpstreamsUpdateByNameInvalid(t *testing.T) {
 
 }
 
+func Test_UpstreamsGetHealthById(t *testing.T) {
+	upstreamRequest := &UpstreamRequest{
+		Name:  "upstream-" + uuid.NewV4().String(),
+		Slots: 10,
+	}
+
+	client := NewClient(NewDefaultConfig())
+	createdUpstream, err := client.Upstreams().Create(upstreamRequest)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, createdUpstream)
+
+	createdTarget, err := client.Targets().CreateFromUpstreamId(createdUpstream.Id, &TargetRequest{
+		Target: "127.0.0.1:8080",
+		Weight: 100,
+	})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, createdTarget)
+
+	result, err := client.Upstreams().GetHealthById(createdUpstream.Id)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, createdUpstream.Id, result.Id)
+	assert.NotEmpty(t, result.Health)
+	assert.Len(t, result.Targets, 1)
+	assert.Equal(t, createdTarget.Target, result.Targets[0].Target)
+
+	client.Targets().DeleteFromUpstreamById(createdUpstream.Id, *createdTarget.Id)
+	client.Upstreams().DeleteById(createdUpstream.Id)
+}
+
+func Test_UpstreamsGetHealthByName(t *testing.T) {
+	upstreamRequest := &UpstreamRequest{
+		Name:  "upstream-" + uuid.NewV4().String(),
+		Slots: 10,
+	}
+
+	client := NewClient(NewDefaultConfig())
+	createdUpstream, err := client.Upstreams().Create(upstreamRequest)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, createdUpstream)
+
+	result, err := client.Upstreams().GetHealthByName(createdUpstream.Name)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, createdUpstream.Id, result.Id)
+	assert.Len(t, result.Targets, 0)
+
+	client.Upstreams().DeleteById(createdUpstream.Id)
+}
+
+func Test_UpstreamsGetHealthForNonExistentUpstream(t *testing.T) {
+
+	result, err := NewClient(NewDefaultConfig()).Upstreams().GetHealthByName(uuid.NewV4().String())
+
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+
+}
+
 func Test_AllUpstreamEndpointsShouldReturnErrorWhenRequestUnauthorised(t *testing.T) {
 
 	unauthorisedClient := NewClient(&Config{HostAddress: kong401Server})
@@ -432,4 +496,14 @@ func Test_AllUpstreamEndpointsShouldReturnErrorWhenRequestUnauthorised(t *testin
 	assert.Nil(t, updatedUpstream)
 	assert.NotNil(t, err)
 
+	upstreamHealth, err := unauthorisedClient.Upstreams().GetHealthByName("foo")
+	assert.Nil(t, upstreamHealth)
+	assert.NotNil(t, err)
+
+	err = unauthorisedClient.Upstreams().SetAddressAsHealthy("foo", "bar:80", "127.0.0.1:80")
+	assert.NotNil(t, err)
+
+	err = unauthorisedClient.Upstreams().SetAddressAsUnhealthy("foo", "bar:80", "127.0.0.1:80")
+	assert.NotNil(t, err)
+
 }
@@ -0,0 +1,254 @@
+package gokong
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+type EntityEventType string
+
+const (
+	EntityAdded   EntityEventType = "added"
+	EntityUpdated EntityEventType = "updated"
+	EntityDeleted EntityEventType = "deleted"
+	// EntityWatchError is sent when an entity type could not be listed, its changes are reported by the next poll.
+	EntityWatchError EntityEventType = "error"
+)
+
+// The entity types a watcher can watch.
+const (
+	WatchServices  = "services"
+	WatchRoutes    = "routes"
+	WatchPlugins   = "plugins"
+	WatchConsumers = "consumers"
+	WatchUpstreams = "upstreams"
+)
+
+var watchEntities = []string{WatchServices, WatchRoutes, WatchPlugins, WatchConsumers, WatchUpstreams}
+
+// EntityEvent is a change of an entity found by a watcher. The field of the entity type is set to the entity, for
+// deleted entities it is the last version seen.
+type EntityEvent struct {
+	Type     EntityEventType
+	Entity   string
+	Id       string
+	Service  *Service
+	Route    *Route
+	Plugin   *Plugin
+	Consumer *Consumer
+	Upstream *Upstream
+	Err      error
+}
+
+type Watch struct {
+	// Interval is the time between polls, 10 seconds when not set.
+	Interval time.Duration
+	// Entities are the entity types to watch, all of them when not set.
+	Entities []string
+}
+
+// Watcher polls kong for changes made by any client, e.g. in kong manager, and reports them as events.
+type Watcher struct {
+	client KongAdminClient
+	watch  *Watch
+}
+
+type watchedEntity struct {
+	updatedAt int
+	hash      [sha256.Size]byte
+	event     *EntityEvent
+}
+
+const defaultWatchInterval = 10 * time.Second
+
+// NewWatcher creates a watcher that lists the entities with the client. The client should not have a cache, or
+// changes are only seen once the cached lists expire.
+func NewWatcher(client KongAdminClient, watch *Watch) *Watcher {
+	if watch == nil {
+		watch = &Watch{}
+	}
+	return &Watcher{client: client, watch: watch}
+}
+
+// Run lists the watched entities every interval and sends an event on the returned channel for every entity added,
+// updated or deleted since the previous poll. The first poll only records the entities. An entity is updated when
+// its updated_at changes or, as not all entities have one, when its content changes. The channel is closed once the
+// context is done.
+func (watcher *Watcher) Run(ctx context.Context) (<-chan *EntityEvent, error) {
+	entities := watcher.watch.Entities
+	if len(entities) == 0 {
+		entities = watchEntities
+	}
+	for _, entity := range entities {
+		if !isWatchable(entity) {
+			return nil, fmt.Errorf("cannot watch %s, the entities that can be watched are %v", entity, watchEntities)
+		}
+	}
+	interval := watcher.watch.Interval
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+
+	client := watcher.client.WithContext(ctx)
+	events := make(chan *EntityEvent)
+	go func() {
+		defer close(events)
+
+		snapshots := make(map[string]map[string]*watchedEntity)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			for _, entity := range entities {
+				current, err := listWatched(client, entity)
+				if err != nil {
+					if ctx.Err() != nil {
+						return
+					}
+					if !sendEvent(ctx, events, &EntityEvent{Type: EntityWatchError, Entity: entity, Err: err}) {
+						return
+					}
+					continue
+				}
+				previous, polled := snapshots[entity]
+				snapshots[entity] = current
+				if !polled {
+					continue
+				}
+				for _, event := range diffWatched(previous, current) {
+					if !sendEvent(ctx, events, event) {
+						return
+					}
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+func isWatchable(entity string) bool {
+	for _, watchable := range watchEntities {
+		if entity == watchable {
+			return true
+		}
+	}
+	return false
+}
+
+func sendEvent(ctx context.Context, events chan<- *EntityEvent, event *EntityEvent) bool {
+	select {
+	case events <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// diffWatched returns the events that turn the previous snapshot into the current one, ordered by id.
+func diffWatched(previous map[string]*watchedEntity, current map[string]*watchedEntity) []*EntityEvent {
+	events := make([]*EntityEvent, 0)
+	for id, entity := range current {
+		before, existed := previous[id]
+		if !existed {
+			events = append(events, withType(entity.event, EntityAdded))
+		} else if before.updatedAt != entity.updatedAt || before.hash != entity.hash {
+			events = append(events, withType(entity.event, EntityUpdated))
+		}
+	}
+	for id, entity := range previous {
+		if _, exists := current[id]; !exists {
+			events = append(events, withType(entity.event, EntityDeleted))
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Id < events[j].Id
+	})
+	return events
+}
+
+func withType(event *EntityEvent, eventType EntityEventType) *EntityEvent {
+	typed := *event
+	typed.Type = eventType
+	return &typed
+}
+
+// listWatched lists the entities of a type, keyed by id, following every page of the list.
+func listWatched(client KongAdminClient, entity string) (map[string]*watchedEntity, error) {
+	events := make([]*EntityEvent, 0)
+	updatedAt := make(map[string]int)
+
+	switch entity {
+	case WatchServices:
+		services, err := client.Services().GetServices(&ServiceQueryString{})
+		if err != nil {
+			return nil, err
+		}
+		for _, service := range services {
+			if service.Id == nil {
+				continue
+			}
+			events = append(events, &EntityEvent{Entity: entity, Id: *service.Id, Service: service})
+			if service.UpdatedAt != nil {
+				updatedAt[*service.Id] = *service.UpdatedAt
+			}
+		}
+	case WatchRoutes:
+		routes, err := client.Routes().List(&RouteQueryString{})
+		if err != nil {
+			return nil, err
+		}
+		for _, route := range routes {
+			if route.Id == nil {
+				continue
+			}
+			events = append(events, &EntityEvent{Entity: entity, Id: *route.Id, Route: route})
+			if route.UpdatedAt != nil {
+				updatedAt[*route.Id] = *route.UpdatedAt
+			}
+		}
+	case WatchPlugins:
+		plugins, err := client.Plugins().List(&PluginQueryString{})
+		if err != nil {
+			return nil, err
+		}
+		for _, plugin := range plugins {
+			events = append(events, &EntityEvent{Entity: entity, Id: plugin.Id, Plugin: plugin})
+		}
+	case WatchConsumers:
+		consumers, err := client.Consumers().List(&ConsumerQueryString{})
+		if err != nil {
+			return nil, err
+		}
+		for _, consumer := range consumers {
+			events = append(events, &EntityEvent{Entity: entity, Id: consumer.Id, Consumer: consumer})
+		}
+	case WatchUpstreams:
+		upstreams, err := client.Upstreams().ListWithQuery(nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, upstream := range upstreams {
+			events = append(events, &EntityEvent{Entity: entity, Id: upstream.Id, Upstream: upstream})
+		}
+	}
+
+	watched := make(map[string]*watchedEntity)
+	for _, event := range events {
+		content, err := json.Marshal([]interface{}{event.Service, event.Route, event.Plugin, event.Consumer, event.Upstream})
+		if err != nil {
+			return nil, fmt.Errorf("could not hash %s %s, error: %v", entity, event.Id, err)
+		}
+		watched[event.Id] = &watchedEntity{updatedAt: updatedAt[event.Id], hash: sha256.Sum256(content), event: event}
+	}
+	return watched, nil
+}
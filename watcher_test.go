@@ -0,0 +1,130 @@
+package gokong
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// listingClient returns a client whose transport answers each list request with the body set for its path, or
+// fails when no body is set. setBody waits for the body set before to be listed, so that the watcher sees both.
+func listingClient() (*kongAdminClient, func(path string, body string)) {
+	var mu sync.Mutex
+	bodies := make(map[string]string)
+	listed := make(map[string]bool)
+	client := NewClient(&Config{
+		HostAddress: "http://kong:8001",
+		HTTPClient: &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			body := bodies[req.URL.Path]
+			listed[req.URL.Path] = true
+			mu.Unlock()
+			if body == "" {
+				return nil, errors.New("connection refused")
+			}
+			return &http.Response{StatusCode: 200, Header: http.Header{}, Body: ioutil.NopCloser(bytes.NewBufferString(body))}, nil
+		})},
+	})
+	return client, func(path string, body string) {
+		for {
+			mu.Lock()
+			if _, set := bodies[path]; !set || listed[path] {
+				bodies[path] = body
+				listed[path] = false
+				mu.Unlock()
+				return
+			}
+			mu.Unlock()
+			time.Sleep(time.Millisecond)
+		}
+	}
+}
+
+func nextEvent(t *testing.T, events <-chan *EntityEvent) *EntityEvent {
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("no event received")
+		return nil
+	}
+}
+
+func Test_WatcherSendsEventsForChangedServices(t *testing.T) {
+	client, setBody := listingClient()
+	setBody("/services/", `{"data": [
+		{"id": "a", "name": "service-a", "updated_at": 1},
+		{"id": "b", "name": "service-b", "updated_at": 1}
+	]}`)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := NewWatcher(client, &Watch{Interval: 10 * time.Millisecond, Entities: []string{WatchServices}}).Run(ctx)
+	assert.Nil(t, err)
+	setBody("/services/", `{"data": [
+		{"id": "a", "name": "service-a", "updated_at": 2},
+		{"id": "c", "name": "service-c", "updated_at": 2}
+	]}`)
+
+	event := nextEvent(t, events)
+	assert.Equal(t, EntityUpdated, event.Type)
+	assert.Equal(t, "a", event.Id)
+	assert.Equal(t, WatchServices, event.Entity)
+	assert.Equal(t, 2, *event.Service.UpdatedAt)
+
+	event = nextEvent(t, events)
+	assert.Equal(t, EntityDeleted, event.Type)
+	assert.Equal(t, "service-b", *event.Service.Name)
+
+	event = nextEvent(t, events)
+	assert.Equal(t, EntityAdded, event.Type)
+	assert.Equal(t, "c", event.Id)
+
+	cancel()
+	for range events {
+	}
+}
+
+func Test_WatcherComparesContentOfEntitiesWithoutUpdatedAt(t *testing.T) {
+	client, setBody := listingClient()
+	setBody("/consumers/", `{"data": [{"id": "a", "username": "user-a"}]}`)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := NewWatcher(client, &Watch{Interval: 10 * time.Millisecond, Entities: []string{WatchConsumers}}).Run(ctx)
+	assert.Nil(t, err)
+	setBody("/consumers/", `{"data": [{"id": "a", "username": "user-a", "custom_id": "123"}]}`)
+
+	event := nextEvent(t, events)
+	assert.Equal(t, EntityUpdated, event.Type)
+	assert.Equal(t, "123", event.Consumer.CustomId)
+}
+
+func Test_WatcherSendsListErrors(t *testing.T) {
+	client, _ := listingClient()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := NewWatcher(client, &Watch{Interval: 10 * time.Millisecond, Entities: []string{WatchUpstreams}}).Run(ctx)
+	assert.Nil(t, err)
+
+	event := nextEvent(t, events)
+	assert.Equal(t, EntityWatchError, event.Type)
+	assert.Equal(t, WatchUpstreams, event.Entity)
+	assert.NotNil(t, event.Err)
+}
+
+func Test_WatcherRejectsUnknownEntities(t *testing.T) {
+	client, _ := listingClient()
+
+	_, err := NewWatcher(client, &Watch{Entities: []string{"certificates"}}).Run(context.Background())
+
+	assert.NotNil(t, err)
+}
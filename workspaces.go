@@ -14,6 +14,9 @@ type WorkspaceClient interface {
 	Update(workspaceRequest *WorkspaceRequest) (*Workspace, error)
 	Delete() error
 	ListEntities() ([]*WorkspaceEntity, error)
+	ListEntitiesWithQuery(query *WorkspaceEntityQueryString) ([]*WorkspaceEntity, error)
+	GetEntity(entityId string) (*WorkspaceEntity, error)
+	AddEntitiesToWorkspace(entityIds []string) error
 	DeleteMultipleEntitiesFromWorkspace(entityIds []string) error
 }
 
@@ -22,8 +25,10 @@ type workspaceClient struct {
 }
 
 type WorkspaceRequest struct {
-	Name    *string `json:"name" yaml:"name"`
-	Comment *string `json:"comment" yaml:"comment"`
+	Name    *string          `json:"name" yaml:"name"`
+	Comment *string          `json:"comment" yaml:"comment"`
+	Config  *WorkspaceConfig `json:"config,omitempty" yaml:"config,omitempty"`
+	Meta    *WorkspaceMeta   `json:"meta,omitempty" yaml:"meta,omitempty"`
 }
 
 type WorkspaceEntitiesRequest struct {
@@ -31,11 +36,39 @@ type WorkspaceEntitiesRequest struct {
 }
 
 type Workspace struct {
-	Id      *string     `json:"id" yaml:"id"`
-	Name    *string     `json:"name" yaml:"name"`
-	Comment *string     `json:"comment" yaml:"comment"`
-	Config  interface{} `json:"config,omitempty" yaml:"config,omitempty"`
-	Meta    interface{} `json:"meta,omitempty" yaml:"meta,omitempty"`
+	Id      *string          `json:"id" yaml:"id"`
+	Name    *string          `json:"name" yaml:"name"`
+	Comment *string          `json:"comment" yaml:"comment"`
+	Config  *WorkspaceConfig `json:"config,omitempty" yaml:"config,omitempty"`
+	Meta    *WorkspaceMeta   `json:"meta,omitempty" yaml:"meta,omitempty"`
+}
+
+// WorkspaceConfig holds the dev portal settings of a workspace. PortalAuthConf and PortalSessionConf are json
+// documents encoded as strings, as kong stores them.
+type WorkspaceConfig struct {
+	Portal                    *bool    `json:"portal,omitempty" yaml:"portal,omitempty"`
+	PortalAuth                *string  `json:"portal_auth,omitempty" yaml:"portal_auth,omitempty"`
+	PortalAuthConf            *string  `json:"portal_auth_conf,omitempty" yaml:"portal_auth_conf,omitempty"`
+	PortalAutoApprove         *bool    `json:"portal_auto_approve,omitempty" yaml:"portal_auto_approve,omitempty"`
+	PortalCorsOrigins         []string `json:"portal_cors_origins,omitempty" yaml:"portal_cors_origins,omitempty"`
+	PortalDeveloperMetaFields *string  `json:"portal_developer_meta_fields,omitempty" yaml:"portal_developer_meta_fields,omitempty"`
+	PortalEmailsFrom          *string  `json:"portal_emails_from,omitempty" yaml:"portal_emails_from,omitempty"`
+	PortalEmailsReplyTo       *string  `json:"portal_emails_reply_to,omitempty" yaml:"portal_emails_reply_to,omitempty"`
+	PortalInviteEmail         *bool    `json:"portal_invite_email,omitempty" yaml:"portal_invite_email,omitempty"`
+	PortalAccessRequestEmail  *bool    `json:"portal_access_request_email,omitempty" yaml:"portal_access_request_email,omitempty"`
+	PortalApprovedEmail       *bool    `json:"portal_approved_email,omitempty" yaml:"portal_approved_email,omitempty"`
+	PortalResetEmail          *bool    `json:"portal_reset_email,omitempty" yaml:"portal_reset_email,omitempty"`
+	PortalResetSuccessEmail   *bool    `json:"portal_reset_success_email,omitempty" yaml:"portal_reset_success_email,omitempty"`
+	PortalTokenExp            *int     `json:"portal_token_exp,omitempty" yaml:"portal_token_exp,omitempty"`
+	PortalSessionConf         *string  `json:"portal_session_conf,omitempty" yaml:"portal_session_conf,omitempty"`
+	PortalIsLegacy            *bool    `json:"portal_is_legacy,omitempty" yaml:"portal_is_legacy,omitempty"`
+}
+
+// WorkspaceMeta holds how kong manager displays a workspace, the color is a hex code such as #1E90FF and the
+// thumbnail a data url.
+type WorkspaceMeta struct {
+	Color     *string `json:"color,omitempty" yaml:"color,omitempty"`
+	Thumbnail *string `json:"thumbnail,omitempty" yaml:"thumbnail,omitempty"`
 }
 
 type Workspaces struct {
@@ -59,8 +92,18 @@ type WorkspaceEntity struct {
 }
 
 type WorkspaceEntities struct {
-	Data  []*WorkspaceEntity `json:"data" yaml:"data,omitempty"`
-	Total int                `json:"total,omitempty" yaml:"total,omitempty"`
+	Data   []*WorkspaceEntity `json:"data" yaml:"data,omitempty"`
+	Total  int                `json:"total,omitempty" yaml:"total,omitempty"`
+	Next   *string            `json:"next,omitempty" yaml:"next,omitempty"`
+	Offset string             `json:"offset,omitempty" yaml:"offset,omitempty"`
+}
+
+// WorkspaceEntityQueryString filters the entities of a workspace, EntityType is the name of the entity collection,
+// e.g. services or routes.
+type WorkspaceEntityQueryString struct {
+	EntityType string `json:"entity_type,omitempty" yaml:"entity_type,omitempty"`
+	Offset     string `json:"offset,omitempty" yaml:"offset,omitempty"`
+	Size       int    `json:"size" yaml:"size,omitempty"`
 }
 
 const WorkspacesPath = "/workspaces/"
@@ -224,14 +267,73 @@ func (workspaceClient *workspaceClient) Delete() error {
 }
 
 func (workspaceClient *workspaceClient) ListEntities() ([]*WorkspaceEntity, error) {
+	return workspaceClient.ListEntitiesWithQuery(&WorkspaceEntityQueryString{})
+}
+
+func (workspaceClient *workspaceClient) ListEntitiesWithQuery(query *WorkspaceEntityQueryString) ([]*WorkspaceEntity, error) {
 	requestPath := fmt.Sprintf(
 		"%s%s/entities",
 		workspaceClient.config.HostAddress+WorkspacesPath,
 		workspaceClient.config.Workspace,
 	)
+	workspaceEntities := make([]*WorkspaceEntity, 0)
+
+	pageQuery := WorkspaceEntityQueryString{}
+	if query != nil {
+		pageQuery = *query
+	}
+
+	if pageQuery.Size < 100 {
+		pageQuery.Size = 100
+	}
+
+	if pageQuery.Size > 1000 {
+		pageQuery.Size = 1000
+	}
+
+	for {
+		data := &WorkspaceEntities{}
+
+		r, body, errs := newRawGet(workspaceClient.config, requestPath).Query(pageQuery).End()
+		if errs != nil {
+			return nil, fmt.Errorf("could not get workspace entities, error: %v", errs)
+		}
+
+		if r.StatusCode == 400 {
+			return nil, fmt.Errorf("bad request, message from kong: %s", body)
+		}
+
+		if r.StatusCode == 401 || r.StatusCode == 403 {
+			return nil, fmt.Errorf("not authorised, message from kong: %s", body)
+		}
+
+		err := json.Unmarshal([]byte(body), data)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse workspace entities list response, error: %v", err)
+		}
+
+		workspaceEntities = append(workspaceEntities, data.Data...)
+
+		if data.Next == nil || *data.Next == "" || data.Offset == "" {
+			break
+		}
+
+		pageQuery.Offset = data.Offset
+	}
+
+	return workspaceEntities, nil
+}
+
+func (workspaceClient *workspaceClient) GetEntity(entityId string) (*WorkspaceEntity, error) {
+	requestPath := fmt.Sprintf(
+		"%s%s/entities/%s",
+		workspaceClient.config.HostAddress+WorkspacesPath,
+		workspaceClient.config.Workspace,
+		entityId,
+	)
 	r, body, errs := newRawGet(workspaceClient.config, requestPath).End()
 	if errs != nil {
-		return nil, fmt.Errorf("could not get workspaces, error: %v", errs)
+		return nil, fmt.Errorf("could not get workspace entity, error: %v", errs)
 	}
 
 	if r.StatusCode == 400 {
@@ -242,13 +344,49 @@ func (workspaceClient *workspaceClient) ListEntities() ([]*WorkspaceEntity, erro
 		return nil, fmt.Errorf("not authorised, message from kong: %s", body)
 	}
 
-	workspaceEntities := &WorkspaceEntities{}
-	err := json.Unmarshal([]byte(body), workspaceEntities)
+	workspaceEntity := &WorkspaceEntity{}
+	err := json.Unmarshal([]byte(body), workspaceEntity)
 	if err != nil {
-		return nil, fmt.Errorf("could not parse workspaces list response, error: %v", err)
+		return nil, fmt.Errorf("could not parse workspace entity get response, error: %v", err)
+	}
+
+	if workspaceEntity.EntityId == nil {
+		return nil, nil
+	}
+
+	return workspaceEntity, nil
+}
+
+// AddEntitiesToWorkspace shares existing entities with the workspace of the client, the entities stay in the
+// workspaces they were created in.
+func (workspaceClient *workspaceClient) AddEntitiesToWorkspace(entityIds []string) error {
+	requestPath := fmt.Sprintf(
+		"%s%s/entities",
+		workspaceClient.config.HostAddress+WorkspacesPath,
+		workspaceClient.config.Workspace,
+	)
+	workspaceEntitiesRequest := &WorkspaceEntitiesRequest{
+		Entities: String(strings.Join(entityIds, ",")),
+	}
+
+	r, body, errs := newRawPost(workspaceClient.config, requestPath).Send(workspaceEntitiesRequest).End()
+	if errs != nil {
+		return fmt.Errorf("could not add workspace entities, error: %v", errs)
+	}
+
+	if r.StatusCode == 400 {
+		return fmt.Errorf("bad request, message from kong: %s", body)
 	}
 
-	return workspaceEntities.Data, nil
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		return fmt.Errorf("not authorised, message from kong: %s", body)
+	}
+
+	if r.StatusCode == 404 {
+		return fmt.Errorf("could not add workspace entities, error: %v", body)
+	}
+
+	return nil
 }
 
 func (workspaceClient *workspaceClient) DeleteMultipleEntitiesFromWorkspace(entityIds []string) error {
@@ -11,6 +11,10 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// workspaceEntitiesVersions are the kong enterprise versions that share entities between workspaces, 2.1 removed the
+// workspace entities endpoints.
+const workspaceEntitiesVersions = "< 2.1"
+
 func Test_WorkspaceClient_Get(t *testing.T) {
 	workspaceRequest := &WorkspaceRequest{
 		Name: String(fmt.Sprintf("workspace-name-%s", uuid.NewV4().String())),
@@ -94,6 +98,8 @@ func Test_Workspaces_GetNonExistent(t *testing.T) {
 }
 
 func Test_WorkspaceClient_ListEntities(t *testing.T) {
+	kongTestContext.SkipUnlessVersion(t, workspaceEntitiesVersions)
+
 	workspaceRequest := &WorkspaceRequest{
 		Name: String(fmt.Sprintf("workspace-name-%s", uuid.NewV4().String())),
 	}
@@ -137,6 +143,8 @@ func Test_WorkspaceClient_ListEntities(t *testing.T) {
 }
 
 func Test_WorkspaceClient_ShouldNotAllowToDeleteWorkspaceWithEntity(t *testing.T) {
+	kongTestContext.SkipUnlessVersion(t, workspaceEntitiesVersions)
+
 	workspaceRequest := &WorkspaceRequest{
 		Name: String("testingworkspace"),
 	}
@@ -190,6 +198,8 @@ func Test_WorkspaceClient_Delete(t *testing.T) {
 }
 
 func Test_WorkspaceClient_DeleteMultipleEntitiesFromWorkspaceByIds(t *testing.T) {
+	kongTestContext.SkipUnlessVersion(t, workspaceEntitiesVersions)
+
 	workspaceRequest := &WorkspaceRequest{
 		Name: String(fmt.Sprintf("workspace-name-%s", uuid.NewV4().String())),
 	}
@@ -239,3 +249,87 @@ func Test_WorkspaceClient_DeleteMultipleEntitiesFromWorkspaceByIds(t *testing.T)
 	err = workspaceClient.Workspaces().Delete()
 	assert.Nil(t, err)
 }
+
+func Test_WorkspaceClient_InWorkspace(t *testing.T) {
+	workspaceRequest := &WorkspaceRequest{
+		Name: String(fmt.Sprintf("workspace-name-%s", uuid.NewV4().String())),
+	}
+
+	config := NewDefaultConfig()
+	config.Workspace = "default"
+	client := NewClient(config)
+	createdWorkspace, err := client.Workspaces().Create(workspaceRequest)
+	assert.Nil(t, err)
+	assert.NotNil(t, createdWorkspace)
+
+	workspaceClient := client.InWorkspace(*workspaceRequest.Name)
+	serviceRequest := &ServiceRequest{
+		Name:     String(fmt.Sprintf("service-name-%s", uuid.NewV4().String())),
+		Protocol: String("http"),
+		Host:     String("foo.com"),
+		Port:     Int(8080),
+	}
+	createdService, err := workspaceClient.Services().Create(serviceRequest)
+	assert.Nil(t, err)
+	assert.NotNil(t, createdService)
+	assert.Equal(t, "default", config.Workspace)
+
+	result, err := client.Services().GetServiceByName(*serviceRequest.Name)
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+
+	err = workspaceClient.Services().DeleteServiceById(*createdService.Id)
+	assert.Nil(t, err)
+
+	err = workspaceClient.Workspaces().Delete()
+	assert.Nil(t, err)
+}
+
+func Test_WorkspaceClient_AddEntitiesToWorkspace(t *testing.T) {
+	kongTestContext.SkipUnlessVersion(t, workspaceEntitiesVersions)
+
+	workspaceRequest := &WorkspaceRequest{
+		Name: String(fmt.Sprintf("workspace-name-%s", uuid.NewV4().String())),
+		Meta: &WorkspaceMeta{Color: String("#1E90FF")},
+	}
+
+	config := NewDefaultConfig()
+	config.Workspace = "default"
+	client := NewClient(config)
+	createdWorkspace, err := client.Workspaces().Create(workspaceRequest)
+	assert.Nil(t, err)
+	assert.NotNil(t, createdWorkspace)
+	assert.Equal(t, "#1E90FF", *createdWorkspace.Meta.Color)
+
+	createdService, err := client.Services().Create(&ServiceRequest{
+		Name:     String(fmt.Sprintf("service-name-%s", uuid.NewV4().String())),
+		Protocol: String("http"),
+		Host:     String("foo.com"),
+		Port:     Int(8080),
+	})
+	assert.Nil(t, err)
+	assert.NotNil(t, createdService)
+
+	workspaceClient := client.InWorkspace(*workspaceRequest.Name)
+	err = workspaceClient.Workspaces().AddEntitiesToWorkspace([]string{*createdService.Id})
+	assert.Nil(t, err)
+
+	workspaceEntities, err := workspaceClient.Workspaces().ListEntitiesWithQuery(&WorkspaceEntityQueryString{EntityType: "services"})
+	assert.Nil(t, err)
+	assert.Len(t, workspaceEntities, 1)
+	assert.Equal(t, *createdService.Id, *workspaceEntities[0].EntityId)
+
+	workspaceEntity, err := workspaceClient.Workspaces().GetEntity(*createdService.Id)
+	assert.Nil(t, err)
+	assert.NotNil(t, workspaceEntity)
+	assert.Equal(t, "services", *workspaceEntity.EntityType)
+
+	err = workspaceClient.Workspaces().DeleteMultipleEntitiesFromWorkspace([]string{*createdService.Id})
+	assert.Nil(t, err)
+
+	err = client.Services().DeleteServiceById(*createdService.Id)
+	assert.Nil(t, err)
+
+	err = workspaceClient.Workspaces().Delete()
+	assert.Nil(t, err)
+}